@@ -0,0 +1,3 @@
+package htmx
+
+//go:generate go run ./internal/codegen
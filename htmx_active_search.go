@@ -0,0 +1,127 @@
+package htmx
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// ActiveSearch is the active search pattern: an input that searches as the
+// user types and swaps the results into a container.
+//
+// https://htmx.org/examples/active-search/
+type ActiveSearch struct {
+	// URL is the search endpoint, usually served by Handler.
+	URL string
+
+	// Name is the name of the input and query parameter. Defaults to "q".
+	Name string
+
+	// ResultsID is the id of the results container. Defaults to
+	// "search-results".
+	ResultsID string
+
+	// IndicatorID, if set, is the id of the element shown while searching.
+	IndicatorID string
+
+	// Placeholder is the input placeholder.
+	Placeholder string
+
+	// Delay is how long to wait after the last keystroke before searching.
+	// Defaults to 500ms.
+	Delay time.Duration
+
+	// MinLength is the minimum query length, shorter queries are treated as
+	// empty.
+	MinLength int
+}
+
+func (s ActiveSearch) name() string {
+	if s.Name == "" {
+		return "q"
+	}
+	return s.Name
+}
+
+func (s ActiveSearch) resultsID() string {
+	if s.ResultsID == "" {
+		return "search-results"
+	}
+	return s.ResultsID
+}
+
+func (s ActiveSearch) delay() time.Duration {
+	if s.Delay <= 0 {
+		return 500 * time.Millisecond
+	}
+	return s.Delay
+}
+
+// Input renders the search input with the given extra attributes and
+// children.
+//
+// Output: <input type="search" name="[Name]" hx-get="[URL]"
+// hx-trigger="input changed delay:[Delay], search" hx-target="#[ResultsID]"
+// hx-sync="this:replace" …>
+func (s ActiveSearch) Input(children ...nodx.Node) nodx.Node {
+	return nodx.Input(
+		nodx.Type("search"),
+		nodx.Name(s.name()),
+		nodx.If(s.Placeholder != "", nodx.Placeholder(s.Placeholder)),
+		HxGet(s.URL),
+		HxTrigger(fmt.Sprintf("input changed delay:%dms, search", s.delay().Milliseconds())),
+		HxTarget("#"+s.resultsID()),
+		HxSyncWith(SelectorThis, SyncReplace),
+		nodx.If(s.IndicatorID != "", HxIndicator("#"+s.IndicatorID)),
+		nodx.Group(children...),
+	)
+}
+
+// Results renders the results container with the given initial children.
+//
+// Output: <div id="[ResultsID]" aria-live="polite">[children]</div>
+func (s ActiveSearch) Results(children ...nodx.Node) nodx.Node {
+	return nodx.Div(
+		nodx.Id(s.resultsID()),
+		nodx.Aria("live", "polite"),
+		nodx.Group(children...),
+	)
+}
+
+// ServerQuery returns the trimmed search query of the request, or an empty
+// string if it is shorter than MinLength.
+func (s ActiveSearch) ServerQuery(r *http.Request) string {
+	query := strings.TrimSpace(r.URL.Query().Get(s.name()))
+	if len([]rune(query)) < s.MinLength {
+		return ""
+	}
+	return query
+}
+
+// Handler returns the handler of the search endpoint.
+//
+// It reads the query with ServerQuery and renders the fragment returned by
+// search, which can be nil when there are no results. Empty queries clear
+// the results without calling search.
+func (s ActiveSearch) Handler(search func(r *http.Request, query string) (nodx.Node, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := s.ServerQuery(r)
+		if query == "" {
+			_ = ServerRender(w, nodx.Group())
+			return
+		}
+
+		results, err := search(r, query)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		if results == nil {
+			results = nodx.Group()
+		}
+		_ = ServerRender(w, results)
+	})
+}
@@ -0,0 +1,72 @@
+package htmx_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func ExampleActiveSearch() {
+	search := htmx.ActiveSearch{
+		URL:         "/search",
+		IndicatorID: "spinner",
+		Placeholder: "Search…",
+	}
+	fmt.Println(search.Input())
+	fmt.Println(search.Results())
+	// Output:
+	// <input type="search" name="q" placeholder="Search…" hx-get="/search" hx-trigger="input changed delay:500ms, search" hx-target="#search-results" hx-sync="this:replace" hx-indicator="#spinner">
+	// <div id="search-results" aria-live="polite"></div>
+}
+
+func TestActiveSearchHandler(t *testing.T) {
+	search := htmx.ActiveSearch{URL: "/search", MinLength: 2}
+	calls := 0
+	handler := search.Handler(func(r *http.Request, query string) (nodx.Node, error) {
+		calls++
+		if query == "fail" {
+			return nil, errors.New("boom")
+		}
+		if query == "none" {
+			return nil, nil
+		}
+		return nodx.Li(nodx.Text(query)), nil
+	})
+
+	tests := []struct {
+		url    string
+		status int
+		body   string
+	}{
+		{"/search?q=+go+", http.StatusOK, "<li>go</li>"},
+		{"/search?q=+", http.StatusOK, ""},
+		{"/search?q=g", http.StatusOK, ""},
+		{"/search?q=fail", http.StatusInternalServerError, "Internal Server Error\n"},
+		{"/search?q=none", http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+		if rec.Code != tt.status || rec.Body.String() != tt.body {
+			t.Errorf("%s: expected %d %q, got %d %q", tt.url, tt.status, tt.body, rec.Code, rec.Body.String())
+		}
+	}
+
+	if calls != 3 {
+		t.Errorf("expected search to be called 3 times, got %d", calls)
+	}
+}
+
+func TestActiveSearchDelay(t *testing.T) {
+	input := (htmx.ActiveSearch{URL: "/search", Delay: time.Second}).Input().String()
+	if !strings.Contains(input, `hx-trigger="input changed delay:1000ms, search"`) {
+		t.Errorf("expected the delay in milliseconds, got %q", input)
+	}
+}
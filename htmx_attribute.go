@@ -0,0 +1,134 @@
+package htmx
+
+import (
+	"strings"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// Attribute is the name of an hx-* attribute defined by this package.
+//
+// https://htmx.org/reference/#attributes
+type Attribute string
+
+// String returns the attribute name.
+func (a Attribute) String() string {
+	return string(a)
+}
+
+// joinAttributes joins the attribute names with spaces.
+func joinAttributes(attributes []Attribute) string {
+	names := make([]string, len(attributes))
+	for i, attribute := range attributes {
+		names[i] = string(attribute)
+	}
+	return strings.Join(names, " ")
+}
+
+// HxDisinheritAll renders an hx-disinherit="*" attribute.
+//
+// Disables the inheritance of all attributes for child nodes.
+//
+// https://htmx.org/attributes/hx-disinherit/
+func HxDisinheritAll() nodx.Node {
+	return HxDisinherit("*")
+}
+
+// HxDisinheritAttributes renders an hx-disinherit="[attribute] [attribute] …" attribute.
+//
+// Disables the inheritance of the given attributes for child nodes.
+//
+// https://htmx.org/attributes/hx-disinherit/
+func HxDisinheritAttributes(attributes ...Attribute) nodx.Node {
+	return HxDisinherit(joinAttributes(attributes))
+}
+
+// HxInheritAll renders an hx-inherit="*" attribute.
+//
+// Enables the inheritance of all attributes for child nodes if it has been
+// disabled by default.
+//
+// https://htmx.org/attributes/hx-inherit/
+func HxInheritAll() nodx.Node {
+	return HxInherit("*")
+}
+
+// HxInheritAttributes renders an hx-inherit="[attribute] [attribute] …" attribute.
+//
+// Enables the inheritance of the given attributes for child nodes if it has
+// been disabled by default.
+//
+// https://htmx.org/attributes/hx-inherit/
+func HxInheritAttributes(attributes ...Attribute) nodx.Node {
+	return HxInherit(joinAttributes(attributes))
+}
+
+// AttributeSpec describes an hx-* attribute.
+type AttributeSpec struct {
+	// Name is the attribute name, e.g. "hx-get".
+	Name Attribute
+
+	// Description is a short summary of what the attribute does.
+	Description string
+
+	// Inherited indicates whether child nodes inherit the attribute.
+	//
+	// https://htmx.org/docs/#inheritance
+	Inherited bool
+
+	// Deprecated indicates whether htmx discourages the use of the attribute.
+	Deprecated bool
+
+	// Extension, if set, is the htmx extension that processes the attribute.
+	// It must be enabled with hx-ext.
+	//
+	// https://htmx.org/extensions/
+	Extension string
+
+	// Versions lists the major htmx versions that support the attribute.
+	Versions []int
+
+	// Value is the grammar of the attribute value, with <placeholders> for
+	// free-form parts and "quoted" literals. It is empty for attributes that
+	// are used without a value.
+	Value string
+
+	// DocURL is the URL of the attribute documentation.
+	DocURL string
+}
+
+// SupportedIn reports whether the attribute is supported by the given major
+// htmx version.
+func (s AttributeSpec) SupportedIn(major int) bool {
+	for _, version := range s.Versions {
+		if version == major {
+			return true
+		}
+	}
+	return false
+}
+
+// Attributes returns the specs of every hx-* attribute that has a helper in
+// this package, in the same order as the Attribute constants.
+//
+// The returned slice is a copy and can be freely modified.
+func Attributes() []AttributeSpec {
+	specs := make([]AttributeSpec, len(attributeSpecs))
+	for i, spec := range attributeSpecs {
+		spec.Versions = append([]int(nil), spec.Versions...)
+		specs[i] = spec
+	}
+	return specs
+}
+
+// LookupAttribute returns the spec of the given attribute and whether it was
+// found.
+func LookupAttribute(name Attribute) (AttributeSpec, bool) {
+	for _, spec := range attributeSpecs {
+		if spec.Name == name {
+			spec.Versions = append([]int(nil), spec.Versions...)
+			return spec, true
+		}
+	}
+	return AttributeSpec{}, false
+}
@@ -0,0 +1,324 @@
+// Code generated by internal/codegen from spec.json. DO NOT EDIT.
+
+package htmx
+
+// Names of every hx-* attribute that has a helper in this package.
+//
+// AttributeOn is the prefix of the hx-on:[eventName] attributes.
+const (
+	AttributeGet         Attribute = "hx-get"
+	AttributePost        Attribute = "hx-post"
+	AttributePut         Attribute = "hx-put"
+	AttributePatch       Attribute = "hx-patch"
+	AttributeDelete      Attribute = "hx-delete"
+	AttributeOn          Attribute = "hx-on"
+	AttributePushURL     Attribute = "hx-push-url"
+	AttributeSelect      Attribute = "hx-select"
+	AttributeSelectOOB   Attribute = "hx-select-oob"
+	AttributeSwap        Attribute = "hx-swap"
+	AttributeSwapOOB     Attribute = "hx-swap-oob"
+	AttributeTarget      Attribute = "hx-target"
+	AttributeTrigger     Attribute = "hx-trigger"
+	AttributeVals        Attribute = "hx-vals"
+	AttributeBoost       Attribute = "hx-boost"
+	AttributeConfirm     Attribute = "hx-confirm"
+	AttributeDisable     Attribute = "hx-disable"
+	AttributeDisabledELT Attribute = "hx-disabled-elt"
+	AttributeDisinherit  Attribute = "hx-disinherit"
+	AttributeEncoding    Attribute = "hx-encoding"
+	AttributeExt         Attribute = "hx-ext"
+	AttributeHeaders     Attribute = "hx-headers"
+	AttributeHistory     Attribute = "hx-history"
+	AttributeHistoryElt  Attribute = "hx-history-elt"
+	AttributeInclude     Attribute = "hx-include"
+	AttributeIndicator   Attribute = "hx-indicator"
+	AttributeInherit     Attribute = "hx-inherit"
+	AttributeParams      Attribute = "hx-params"
+	AttributePreserve    Attribute = "hx-preserve"
+	AttributePrompt      Attribute = "hx-prompt"
+	AttributeReplaceURL  Attribute = "hx-replace-url"
+	AttributeRequest     Attribute = "hx-request"
+	AttributeSync        Attribute = "hx-sync"
+	AttributeValidate    Attribute = "hx-validate"
+	AttributeVars        Attribute = "hx-vars"
+	AttributeHead        Attribute = "hx-head"
+)
+
+// attributeSpecs is the registry backing Attributes and LookupAttribute.
+var attributeSpecs = []AttributeSpec{
+	{
+		Name:        AttributeGet,
+		Description: "Issues a GET to the specified URL.",
+		Versions:    []int{1, 2},
+		Value:       "<url>",
+		DocURL:      "https://htmx.org/attributes/hx-get/",
+	},
+	{
+		Name:        AttributePost,
+		Description: "Issues a POST to the specified URL.",
+		Versions:    []int{1, 2},
+		Value:       "<url>",
+		DocURL:      "https://htmx.org/attributes/hx-post/",
+	},
+	{
+		Name:        AttributePut,
+		Description: "Issues a PUT to the specified URL.",
+		Versions:    []int{1, 2},
+		Value:       "<url>",
+		DocURL:      "https://htmx.org/attributes/hx-put/",
+	},
+	{
+		Name:        AttributePatch,
+		Description: "Issues a PATCH to the specified URL.",
+		Versions:    []int{1, 2},
+		Value:       "<url>",
+		DocURL:      "https://htmx.org/attributes/hx-patch/",
+	},
+	{
+		Name:        AttributeDelete,
+		Description: "Issues a DELETE to the specified URL.",
+		Versions:    []int{1, 2},
+		Value:       "<url>",
+		DocURL:      "https://htmx.org/attributes/hx-delete/",
+	},
+	{
+		Name:        AttributeOn,
+		Description: "Handle events with inline scripts on elements.",
+		Versions:    []int{1, 2},
+		Value:       "<javascript>",
+		DocURL:      "https://htmx.org/attributes/hx-on/",
+	},
+	{
+		Name:        AttributePushURL,
+		Description: "Push a URL into the browser location bar to create history.",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       `"true" | "false" | <url>`,
+		DocURL:      "https://htmx.org/attributes/hx-push-url/",
+	},
+	{
+		Name:        AttributeSelect,
+		Description: "Select content to swap in from a response.",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       "<css selector>",
+		DocURL:      "https://htmx.org/attributes/hx-select/",
+	},
+	{
+		Name:        AttributeSelectOOB,
+		Description: "Select content to swap in from a response, somewhere other than the target (out of band).",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       "<css selector>[:<swap style>], …",
+		DocURL:      "https://htmx.org/attributes/hx-select-oob/",
+	},
+	{
+		Name:        AttributeSwap,
+		Description: "Controls how content will swap in (outerHTML, beforeend, afterend, …).",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       "<swap style> [<modifier> …]",
+		DocURL:      "https://htmx.org/attributes/hx-swap/",
+	},
+	{
+		Name:        AttributeSwapOOB,
+		Description: "Mark element to swap in from a response (out of band).",
+		Versions:    []int{1, 2},
+		Value:       `"true" | <swap style>[:<css selector>]`,
+		DocURL:      "https://htmx.org/attributes/hx-swap-oob/",
+	},
+	{
+		Name:        AttributeTarget,
+		Description: "Specifies the target element to be swapped.",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       "<extended css selector>",
+		DocURL:      "https://htmx.org/attributes/hx-target/",
+	},
+	{
+		Name:        AttributeTrigger,
+		Description: "Specifies the event that triggers the request.",
+		Versions:    []int{1, 2},
+		Value:       "<event>[<filter>] [<modifier> …], …",
+		DocURL:      "https://htmx.org/attributes/hx-trigger/",
+	},
+	{
+		Name:        AttributeVals,
+		Description: "Add values to submit with the request (JSON format).",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       `<json> | "js:" <javascript>`,
+		DocURL:      "https://htmx.org/attributes/hx-vals/",
+	},
+	{
+		Name:        AttributeBoost,
+		Description: "Add progressive enhancement for links and forms.",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       `"true" | "false"`,
+		DocURL:      "https://htmx.org/attributes/hx-boost/",
+	},
+	{
+		Name:        AttributeConfirm,
+		Description: "Shows a confirm() dialog before issuing a request.",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       "<message>",
+		DocURL:      "https://htmx.org/attributes/hx-confirm/",
+	},
+	{
+		Name:        AttributeDisable,
+		Description: "Disables htmx processing for the given node and any children nodes.",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       "",
+		DocURL:      "https://htmx.org/attributes/hx-disable/",
+	},
+	{
+		Name:        AttributeDisabledELT,
+		Description: "Adds the disabled attribute to the specified elements while a request is in flight.",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       "<extended css selector>, …",
+		DocURL:      "https://htmx.org/attributes/hx-disabled-elt/",
+	},
+	{
+		Name:        AttributeDisinherit,
+		Description: "Control and disable automatic attribute inheritance for child nodes.",
+		Versions:    []int{1, 2},
+		Value:       `"*" | <attribute> [<attribute> …]`,
+		DocURL:      "https://htmx.org/attributes/hx-disinherit/",
+	},
+	{
+		Name:        AttributeEncoding,
+		Description: "Changes the request encoding type.",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       `"multipart/form-data"`,
+		DocURL:      "https://htmx.org/attributes/hx-encoding/",
+	},
+	{
+		Name:        AttributeExt,
+		Description: "Extensions to use for this element.",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       `<extension>, … | "ignore:" <extension>`,
+		DocURL:      "https://htmx.org/attributes/hx-ext/",
+	},
+	{
+		Name:        AttributeHeaders,
+		Description: "Adds to the headers that will be submitted with the request.",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       `<json> | "js:" <javascript>`,
+		DocURL:      "https://htmx.org/attributes/hx-headers/",
+	},
+	{
+		Name:        AttributeHistory,
+		Description: "Prevent sensitive data being saved to the history cache.",
+		Versions:    []int{1, 2},
+		Value:       `"false"`,
+		DocURL:      "https://htmx.org/attributes/hx-history/",
+	},
+	{
+		Name:        AttributeHistoryElt,
+		Description: "The element to snapshot and restore during history navigation.",
+		Versions:    []int{1, 2},
+		Value:       "",
+		DocURL:      "https://htmx.org/attributes/hx-history-elt/",
+	},
+	{
+		Name:        AttributeInclude,
+		Description: "Include additional data in requests.",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       "<extended css selector>",
+		DocURL:      "https://htmx.org/attributes/hx-include/",
+	},
+	{
+		Name:        AttributeIndicator,
+		Description: "The element to put the htmx-request class on during the request.",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       "<extended css selector>",
+		DocURL:      "https://htmx.org/attributes/hx-indicator/",
+	},
+	{
+		Name:        AttributeInherit,
+		Description: "Control and enable automatic attribute inheritance for child nodes if it has been disabled by default.",
+		Versions:    []int{2},
+		Value:       `"*" | <attribute> [<attribute> …]`,
+		DocURL:      "https://htmx.org/attributes/hx-inherit/",
+	},
+	{
+		Name:        AttributeParams,
+		Description: "Filters the parameters that will be submitted with a request.",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       `"*" | "none" | "not " <name>, … | <name>, …`,
+		DocURL:      "https://htmx.org/attributes/hx-params/",
+	},
+	{
+		Name:        AttributePreserve,
+		Description: "Specifies elements to keep unchanged between requests.",
+		Versions:    []int{1, 2},
+		Value:       "",
+		DocURL:      "https://htmx.org/attributes/hx-preserve/",
+	},
+	{
+		Name:        AttributePrompt,
+		Description: "Shows a prompt() before submitting a request.",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       "<message>",
+		DocURL:      "https://htmx.org/attributes/hx-prompt/",
+	},
+	{
+		Name:        AttributeReplaceURL,
+		Description: "Replace the URL in the browser location bar.",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       `"true" | "false" | <url>`,
+		DocURL:      "https://htmx.org/attributes/hx-replace-url/",
+	},
+	{
+		Name:        AttributeRequest,
+		Description: "Configures various aspects of the request.",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       `<json> | "js:" <javascript>`,
+		DocURL:      "https://htmx.org/attributes/hx-request/",
+	},
+	{
+		Name:        AttributeSync,
+		Description: "Control how requests made by different elements are synchronized.",
+		Inherited:   true,
+		Versions:    []int{1, 2},
+		Value:       "<extended css selector>[:<sync strategy>]",
+		DocURL:      "https://htmx.org/attributes/hx-sync/",
+	},
+	{
+		Name:        AttributeValidate,
+		Description: "Force elements to validate themselves before a request.",
+		Versions:    []int{1, 2},
+		Value:       `"true" | "false"`,
+		DocURL:      "https://htmx.org/attributes/hx-validate/",
+	},
+	{
+		Name:        AttributeVars,
+		Description: "Adds values dynamically to the parameters to submit with the request (deprecated, please use hx-vals).",
+		Inherited:   true,
+		Deprecated:  true,
+		Versions:    []int{1, 2},
+		Value:       "<javascript>",
+		DocURL:      "https://htmx.org/attributes/hx-vars/",
+	},
+	{
+		Name:        AttributeHead,
+		Description: "Sets how a head element or one of its children is processed on boosted navigation.",
+		Extension:   "head-support",
+		Versions:    []int{1, 2},
+		Value:       `"merge" | "append" | "re-eval"`,
+		DocURL:      "https://htmx.org/extensions/head-support/",
+	},
+}
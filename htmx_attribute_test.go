@@ -0,0 +1,109 @@
+package htmx_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func ExampleHxDisinheritAll() {
+	node := nodx.Div(
+		htmx.HxDisinheritAll(),
+	)
+	fmt.Println(node)
+	// Output: <div hx-disinherit="*"></div>
+}
+
+func ExampleHxDisinheritAttributes() {
+	node := nodx.Div(
+		htmx.HxDisinheritAttributes(htmx.AttributeTarget, htmx.AttributeSwap),
+	)
+	fmt.Println(node)
+	// Output: <div hx-disinherit="hx-target hx-swap"></div>
+}
+
+func ExampleHxInheritAll() {
+	node := nodx.Div(
+		htmx.HxInheritAll(),
+	)
+	fmt.Println(node)
+	// Output: <div hx-inherit="*"></div>
+}
+
+func ExampleHxInheritAttributes() {
+	node := nodx.Div(
+		htmx.HxInheritAttributes(htmx.AttributeTarget, htmx.AttributeSelect),
+	)
+	fmt.Println(node)
+	// Output: <div hx-inherit="hx-target hx-select"></div>
+}
+
+func ExampleLookupAttribute() {
+	spec, ok := htmx.LookupAttribute(htmx.AttributeTarget)
+	fmt.Println(ok, spec.Inherited, spec.SupportedIn(2))
+	fmt.Println(spec.DocURL)
+	// Output:
+	// true true true
+	// https://htmx.org/attributes/hx-target/
+}
+
+func TestAttributes(t *testing.T) {
+	seen := map[htmx.Attribute]bool{}
+	for _, spec := range htmx.Attributes() {
+		if seen[spec.Name] {
+			t.Errorf("duplicate attribute %q", spec.Name)
+		}
+		seen[spec.Name] = true
+
+		if !strings.HasPrefix(spec.Name.String(), "hx-") {
+			t.Errorf("attribute %q: expected hx- prefix", spec.Name)
+		}
+		expected := "https://htmx.org/attributes/" + spec.Name.String() + "/"
+		if spec.Extension != "" {
+			expected = "https://htmx.org/extensions/" + spec.Extension + "/"
+		}
+		if spec.DocURL != expected {
+			t.Errorf("attribute %q: expected doc URL %q, got %q", spec.Name, expected, spec.DocURL)
+		}
+		if spec.Description == "" {
+			t.Errorf("attribute %q: expected a description", spec.Name)
+		}
+		if len(spec.Versions) == 0 {
+			t.Errorf("attribute %q: expected at least one version", spec.Name)
+		}
+	}
+
+	if len(seen) != 36 {
+		t.Errorf("expected 36 attributes, got %d", len(seen))
+	}
+}
+
+func TestAttributesReturnsCopy(t *testing.T) {
+	specs := htmx.Attributes()
+	specs[0].Versions[0] = 99
+	specs[0].Name = "hx-changed"
+
+	spec, ok := htmx.LookupAttribute(htmx.AttributeGet)
+	if !ok || spec.SupportedIn(99) {
+		t.Error("expected the registry to be unaffected by changes to the returned slice")
+	}
+}
+
+func TestLookupAttributeUnknown(t *testing.T) {
+	if _, ok := htmx.LookupAttribute("hx-unknown"); ok {
+		t.Error("expected unknown attribute not to be found")
+	}
+}
+
+func TestAttributeSpecSupportedIn(t *testing.T) {
+	spec, _ := htmx.LookupAttribute(htmx.AttributeInherit)
+	if spec.SupportedIn(1) {
+		t.Error("expected hx-inherit not to be supported in htmx 1")
+	}
+	if !spec.SupportedIn(2) {
+		t.Error("expected hx-inherit to be supported in htmx 2")
+	}
+}
@@ -0,0 +1,184 @@
+package htmx
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// CacheConfig configures CacheMiddleware.
+type CacheConfig struct {
+	// ETag enables the generation of an ETag header from the rendered bytes
+	// of successful GET responses, answering 304 Not Modified when it matches
+	// the If-None-Match request header.
+	//
+	// HEAD responses are left untouched: handlers often skip the body for
+	// them, so a hash of it would not match the GET ETag.
+	//
+	// The response is buffered to compute the ETag, unless the handler
+	// flushes it. Responses that already have an ETag are left untouched.
+	ETag bool
+}
+
+// CacheMiddleware makes htmx responses safe to cache by browsers and CDNs.
+//
+// It appends "HX-Request", "HX-Boosted" and "HX-History-Restore-Request" to
+// the Vary header of every response, because the same URL can return a
+// fragment, a boosted page or a full page depending on them. Handlers that
+// also render differently depending on the target or trigger element can opt
+// in with ServerVaryOnTarget and ServerVaryOnTrigger.
+//
+// When CacheConfig.ETag is enabled it must be installed outside
+// ResponseMiddleware so the ETag covers the out of band fragments.
+func CacheMiddleware(config CacheConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ServerVary(w.Header(), "HX-Request", "HX-Boosted", "HX-History-Restore-Request")
+
+			if !config.ETag || r.Method != http.MethodGet {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ew := &etagWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(ew, r)
+			ew.finish(r)
+		})
+	}
+}
+
+// ServerVary appends the given header names to the Vary response header,
+// skipping the ones already present.
+func ServerVary(headers http.Header, names ...string) {
+	values := []string{}
+	seen := map[string]bool{}
+	for _, line := range headers.Values("Vary") {
+		for _, value := range strings.Split(line, ",") {
+			value = strings.TrimSpace(value)
+			if value == "" || seen[strings.ToLower(value)] {
+				continue
+			}
+			seen[strings.ToLower(value)] = true
+			values = append(values, value)
+		}
+	}
+
+	changed := false
+	for _, name := range names {
+		if seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+		values = append(values, name)
+		changed = true
+	}
+
+	if changed {
+		headers.Set("Vary", strings.Join(values, ", "))
+	}
+}
+
+// ServerVaryOnTarget appends "HX-Target" to the Vary response header.
+//
+// Use it in handlers whose output depends on ServerGetTarget.
+func ServerVaryOnTarget(headers http.Header) {
+	ServerVary(headers, "HX-Target")
+}
+
+// ServerVaryOnTrigger appends "HX-Trigger" and "HX-Trigger-Name" to the Vary
+// response header.
+//
+// Use it in handlers whose output depends on ServerGetTrigger or
+// ServerGetTriggerName.
+func ServerVaryOnTrigger(headers http.Header) {
+	ServerVary(headers, "HX-Trigger", "HX-Trigger-Name")
+}
+
+// etagWriter buffers the response to compute its ETag.
+type etagWriter struct {
+	http.ResponseWriter
+	buf         bytes.Buffer
+	status      int
+	wroteHeader bool
+	// streaming indicates that the handler flushed the response, so it is
+	// written through without an ETag.
+	streaming bool
+}
+
+func (w *etagWriter) WriteHeader(status int) {
+	if w.streaming {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if w.wroteHeader || status < 200 {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+}
+
+func (w *etagWriter) Write(b []byte) (int, error) {
+	if w.streaming {
+		return w.ResponseWriter.Write(b)
+	}
+	w.wroteHeader = true
+	return w.buf.Write(b)
+}
+
+// Flush implements http.Flusher, giving up on the ETag and writing the
+// buffered response through.
+func (w *etagWriter) Flush() {
+	if !w.streaming {
+		w.streaming = true
+		w.ResponseWriter.WriteHeader(w.status)
+		_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+		w.buf.Reset()
+	}
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController.
+func (w *etagWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// finish sets the ETag and writes the buffered response, or 304 Not Modified.
+func (w *etagWriter) finish(r *http.Request) {
+	if w.streaming {
+		return
+	}
+
+	headers := w.Header()
+	if w.status == http.StatusOK && headers.Get("ETag") == "" {
+		sum := sha256.Sum256(w.buf.Bytes())
+		headers.Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	}
+
+	if w.status == http.StatusOK && etagMatches(r.Header.Get("If-None-Match"), headers.Get("ETag")) {
+		headers.Del("Content-Length")
+		headers.Del("Content-Type")
+		w.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+}
+
+// etagMatches reports whether the If-None-Match header matches the ETag,
+// using the weak comparison required for GET requests.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package htmx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCacheMiddlewareVary(t *testing.T) {
+	handler := CacheMiddleware(CacheConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServerVaryOnTarget(w.Header())
+		_, _ = w.Write([]byte("ok"))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newHtmxRequest(http.MethodGet, "/"))
+
+	expected := "HX-Request, HX-Boosted, HX-History-Restore-Request, HX-Target"
+	if got := rec.Header().Get("Vary"); got != expected {
+		t.Errorf("Vary: expected %q, got %q", expected, got)
+	}
+	if got := rec.Header().Get("ETag"); got != "" {
+		t.Errorf("ETag: expected empty when disabled, got %q", got)
+	}
+}
+
+func TestServerVary(t *testing.T) {
+	headers := http.Header{}
+	headers.Add("Vary", "Accept-Encoding")
+	headers.Add("Vary", "hx-request")
+	ServerVary(headers, "HX-Request", "Cookie")
+	ServerVaryOnTrigger(headers)
+
+	expected := "Accept-Encoding, hx-request, Cookie, HX-Trigger, HX-Trigger-Name"
+	if got := headers.Get("Vary"); got != expected {
+		t.Errorf("Vary: expected %q, got %q", expected, got)
+	}
+}
+
+func TestCacheMiddlewareETag(t *testing.T) {
+	handler := CacheMiddleware(CacheConfig{ETag: true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<p>fragment</p>"))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newHtmxRequest(http.MethodGet, "/"))
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected an ETag")
+	}
+	if rec.Code != http.StatusOK || rec.Body.String() != "<p>fragment</p>" {
+		t.Errorf("unexpected response %d %q", rec.Code, rec.Body.String())
+	}
+
+	r := newHtmxRequest(http.MethodGet, "/")
+	r.Header.Set("If-None-Match", `"other", W/`+etag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("expected 304 without body, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCacheMiddlewareETagSkipped(t *testing.T) {
+	handler := CacheMiddleware(CacheConfig{ETag: true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("created"))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	if got := rec.Header().Get("ETag"); got != "" {
+		t.Errorf("ETag: expected empty for POST, got %q", got)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/", nil))
+	if got := rec.Header().Get("ETag"); got != "" {
+		t.Errorf("ETag: expected empty for HEAD, got %q", got)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if got := rec.Header().Get("ETag"); got != "" || rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 without ETag, got %d %q", rec.Code, got)
+	}
+}
+
+func TestCacheMiddlewareFlush(t *testing.T) {
+	handler := CacheMiddleware(CacheConfig{ETag: true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("a"))
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte("b"))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if got := rec.Header().Get("ETag"); got != "" {
+		t.Errorf("ETag: expected empty for flushed responses, got %q", got)
+	}
+	if got := rec.Body.String(); got != "ab" {
+		t.Errorf("body: expected %q, got %q", "ab", got)
+	}
+}
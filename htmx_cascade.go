@@ -0,0 +1,162 @@
+package htmx
+
+import (
+	"context"
+	"maps"
+	"net/http"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// SelectOption is an option of a select element.
+type SelectOption struct {
+	Value string
+	Label string
+}
+
+// CascadeLevel is a select of a Cascade.
+type CascadeLevel struct {
+	// Name is the name of the select.
+	Name string
+
+	// Label is the accessible label of the select.
+	Label string
+
+	// Placeholder is the label of the empty option shown first.
+	Placeholder string
+
+	// Load returns the options of the select given the selected values of
+	// the previous levels, keyed by name. It can return ErrNotFound.
+	Load func(ctx context.Context, parents map[string]string) ([]SelectOption, error)
+}
+
+// Cascade is the cascading select pattern: a chain of selects where
+// changing one loads the options of the next, e.g. country, region and
+// city.
+//
+// It is also the http.Handler of its URL: the changed select is identified
+// by the "HX-Trigger-Name" request header, and the response replaces the
+// next select with its new options and clears the deeper ones.
+//
+// https://htmx.org/examples/value-select/
+type Cascade struct {
+	// ID is the id of the container. Defaults to "cascade". The select ids
+	// are the container id followed by "-" and the level name.
+	ID string
+
+	// URL is the endpoint that loads the options, served by the Cascade.
+	URL string
+
+	// Levels are the selects, in order.
+	Levels []CascadeLevel
+}
+
+func (c Cascade) id() string {
+	if c.ID == "" {
+		return "cascade"
+	}
+	return c.ID
+}
+
+func (c Cascade) selectID(level CascadeLevel) string {
+	return c.id() + "-" + level.Name
+}
+
+// Render renders the selects with the given selected values, keyed by name.
+//
+// The options of a level are loaded only if the previous level has a value,
+// so the selected values can be empty for a blank form.
+//
+// Output: <div id="[ID]"><select id="[ID]-[Name]" name="[Name]" hx-get="[URL]" hx-trigger="change" hx-target="#[ID]-[next Name]" …>…</select>…</div>
+func (c Cascade) Render(ctx context.Context, values map[string]string) (nodx.Node, error) {
+	selects := make([]nodx.Node, len(c.Levels))
+	parents := map[string]string{}
+	for i, level := range c.Levels {
+		var options []SelectOption
+		if i == 0 || parents[c.Levels[i-1].Name] != "" {
+			var err error
+			options, err = level.Load(ctx, maps.Clone(parents))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		selects[i] = c.renderSelect(i, options, values[level.Name], false)
+		parents[level.Name] = values[level.Name]
+	}
+
+	return nodx.Div(nodx.Id(c.id()), nodx.Group(selects...)), nil
+}
+
+// renderSelect renders the select of the level with the given index.
+func (c Cascade) renderSelect(i int, options []SelectOption, selected string, oob bool) nodx.Node {
+	level := c.Levels[i]
+
+	request := nodx.Group()
+	if i < len(c.Levels)-1 {
+		request = nodx.Group(
+			HxGet(c.URL),
+			HxTrigger("change"),
+			HxTarget("#"+c.selectID(c.Levels[i+1])),
+			HxSwap("outerHTML"),
+			HxInclude("#"+c.id()),
+		)
+	}
+
+	return nodx.Select(
+		nodx.Id(c.selectID(level)),
+		nodx.Name(level.Name),
+		nodx.If(level.Label != "", nodx.Aria("label", level.Label)),
+		request,
+		nodx.If(oob, HxSwapOOB("true")),
+		nodx.Option(nodx.Value(""), nodx.Text(level.Placeholder)),
+		nodx.Map(options, func(option SelectOption) nodx.Node {
+			return nodx.Option(
+				nodx.Value(option.Value),
+				nodx.If(option.Value == selected, nodx.Selected("")),
+				nodx.Text(option.Label),
+			)
+		}),
+	)
+}
+
+// ServeHTTP loads the options of the select following the changed one and
+// renders it, along with out of band swaps clearing the deeper selects.
+//
+// Requests not triggered by one of the selects but the last get a 400
+// status code.
+func (c Cascade) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := ServerGetTriggerName(r.Header)
+	changed := -1
+	for i, level := range c.Levels[:max(len(c.Levels)-1, 0)] {
+		if level.Name == name {
+			changed = i
+		}
+	}
+	if changed < 0 {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	parents := map[string]string{}
+	for _, level := range c.Levels[:changed+1] {
+		parents[level.Name] = query.Get(level.Name)
+	}
+
+	var options []SelectOption
+	if parents[name] != "" {
+		var err error
+		options, err = c.Levels[changed+1].Load(r.Context(), parents)
+		if err != nil {
+			serverComponentError(w, err)
+			return
+		}
+	}
+
+	nodes := []nodx.Node{c.renderSelect(changed+1, options, "", false)}
+	for i := changed + 2; i < len(c.Levels); i++ {
+		nodes = append(nodes, c.renderSelect(i, nil, "", true))
+	}
+	_ = ServerRender(w, nodx.Group(nodes...))
+}
@@ -0,0 +1,86 @@
+package htmx_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+var locationCascade = htmx.Cascade{
+	ID:  "location",
+	URL: "/locations",
+	Levels: []htmx.CascadeLevel{
+		{
+			Name:        "country",
+			Placeholder: "Country",
+			Load: func(ctx context.Context, parents map[string]string) ([]htmx.SelectOption, error) {
+				return []htmx.SelectOption{{Value: "es", Label: "Spain"}}, nil
+			},
+		},
+		{
+			Name:        "region",
+			Placeholder: "Region",
+			Load: func(ctx context.Context, parents map[string]string) ([]htmx.SelectOption, error) {
+				if parents["country"] != "es" {
+					return nil, htmx.ErrNotFound
+				}
+				return []htmx.SelectOption{{Value: "an", Label: "Andalusia"}}, nil
+			},
+		},
+		{
+			Name:        "city",
+			Label:       "City",
+			Placeholder: "City",
+			Load: func(ctx context.Context, parents map[string]string) ([]htmx.SelectOption, error) {
+				return []htmx.SelectOption{{Value: "sev", Label: "Seville"}}, nil
+			},
+		},
+	},
+}
+
+func ExampleCascade() {
+	node, err := locationCascade.Render(context.Background(), map[string]string{"country": "es"})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(node)
+	// Output:
+	// <div id="location"><select id="location-country" name="country" hx-get="/locations" hx-trigger="change" hx-target="#location-region" hx-swap="outerHTML" hx-include="#location"><option value="">Country</option><option value="es" selected="">Spain</option></select><select id="location-region" name="region" hx-get="/locations" hx-trigger="change" hx-target="#location-city" hx-swap="outerHTML" hx-include="#location"><option value="">Region</option><option value="an">Andalusia</option></select><select id="location-city" name="city" aria-label="City"><option value="">City</option></select></div>
+}
+
+func TestCascadeHandler(t *testing.T) {
+	tests := []struct {
+		trigger string
+		query   string
+		status  int
+		body    string
+	}{
+		{
+			"country", "?country=es&region=xx&city=yy", http.StatusOK,
+			`<select id="location-region" name="region" hx-get="/locations" hx-trigger="change" hx-target="#location-city" hx-swap="outerHTML" hx-include="#location"><option value="">Region</option><option value="an">Andalusia</option></select>` +
+				`<select id="location-city" name="city" aria-label="City" hx-swap-oob="true"><option value="">City</option></select>`,
+		},
+		{
+			"region", "?country=es&region=", http.StatusOK,
+			`<select id="location-city" name="city" aria-label="City"><option value="">City</option></select>`,
+		},
+		{"country", "?country=fr", http.StatusNotFound, "Not Found\n"},
+		{"city", "?city=sev", http.StatusBadRequest, "Bad Request\n"},
+		{"", "", http.StatusBadRequest, "Bad Request\n"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/locations"+tt.query, nil)
+		r.Header.Set("HX-Request", "true")
+		r.Header.Set("HX-Trigger-Name", tt.trigger)
+		rec := httptest.NewRecorder()
+		locationCascade.ServeHTTP(rec, r)
+
+		if rec.Code != tt.status || rec.Body.String() != tt.body {
+			t.Errorf("%s%s: expected %d %q, got %d %q", tt.trigger, tt.query, tt.status, tt.body, rec.Code, rec.Body.String())
+		}
+	}
+}
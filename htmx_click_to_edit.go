@@ -0,0 +1,138 @@
+package htmx
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// ErrNotFound can be returned by component callbacks when the requested item
+// does not exist, so the handler responds with a 404 status code.
+var ErrNotFound = errors.New("not found")
+
+// ClickToEditAttrs are the attributes the ClickToEdit render functions must
+// place on their elements.
+type ClickToEditAttrs struct {
+	// Root goes on the root element of the fragment, which is replaced by
+	// every response.
+	Root nodx.Node
+
+	// Action goes on the element that starts the main action: the edit
+	// button in the view, and the form in the edit form.
+	Action nodx.Node
+
+	// Cancel goes on the cancel button of the edit form, and is empty in the
+	// view.
+	Cancel nodx.Node
+}
+
+// ClickToEdit is the click to edit pattern: a view of an item with an edit
+// button that swaps it for an edit form, which in turn is swapped back for
+// the view when it is saved or canceled.
+//
+// It is also the http.Handler of the item URL and of its edit form URL
+// (the item URL followed by "/edit"), and must be mounted on both.
+//
+// https://htmx.org/examples/click-to-edit/
+type ClickToEdit[T any] struct {
+	// URL returns the URL of the item.
+	URL func(item T) string
+
+	// View renders the view of the item.
+	View func(item T, attrs ClickToEditAttrs) nodx.Node
+
+	// Edit renders the edit form of the item with the validation errors of
+	// the last save, if any.
+	Edit func(item T, attrs ClickToEditAttrs, errs ValidationErrors) nodx.Node
+
+	// Load loads the item of the request. It can return ErrNotFound.
+	Load func(r *http.Request) (T, error)
+
+	// Save updates the item with the form values of the request and returns
+	// the saved item. It can return ValidationErrors, along with the item
+	// holding the submitted values, to re-render the form, or ErrNotFound.
+	// Empty ValidationErrors are treated as a successful save.
+	Save func(r *http.Request, item T) (T, error)
+}
+
+// RenderView renders the view of the item.
+//
+// Output: View(item, {Root: hx-target="this" hx-swap="outerHTML", Action: hx-get="[URL]/edit"})
+func (c ClickToEdit[T]) RenderView(item T) nodx.Node {
+	url := c.URL(item)
+	return c.View(item, ClickToEditAttrs{
+		Root:   nodx.Group(HxTarget(SelectorThis), HxSwap("outerHTML")),
+		Action: HxGet(url + "/edit"),
+		Cancel: nodx.Group(),
+	})
+}
+
+// RenderEdit renders the edit form of the item with the given validation
+// errors, which can be nil.
+//
+// Output: Edit(item, {Root: hx-target="this" hx-swap="outerHTML", Action: hx-put="[URL]", Cancel: hx-get="[URL]"}, errs)
+func (c ClickToEdit[T]) RenderEdit(item T, errs ValidationErrors) nodx.Node {
+	url := c.URL(item)
+	return c.Edit(item, ClickToEditAttrs{
+		Root:   nodx.Group(HxTarget(SelectorThis), HxSwap("outerHTML")),
+		Action: HxPut(url),
+		Cancel: HxGet(url),
+	}, errs)
+}
+
+// ServeHTTP renders the view on GET, the edit form on GET of the "/edit"
+// URL, and saves the item on PUT.
+//
+// Successful saves render the view. Validation errors re-render the edit
+// form with a 200 status code so htmx swaps it.
+func (c ClickToEdit[T]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	edit := strings.HasSuffix(r.URL.Path, "/edit")
+	if r.Method != http.MethodGet && (r.Method != http.MethodPut || edit) {
+		allow := http.MethodGet
+		if !edit {
+			allow += ", " + http.MethodPut
+		}
+		w.Header().Set("Allow", allow)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	item, err := c.Load(r)
+	if err != nil {
+		serverComponentError(w, err)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		if edit {
+			_ = ServerRender(w, c.RenderEdit(item, nil))
+			return
+		}
+		_ = ServerRender(w, c.RenderView(item))
+		return
+	}
+
+	saved, err := c.Save(r, item)
+	errs, err := validationErrors(err)
+	if len(errs) > 0 {
+		_ = ServerRender(w, c.RenderEdit(saved, errs))
+		return
+	}
+	if err != nil {
+		serverComponentError(w, err)
+		return
+	}
+	_ = ServerRender(w, c.RenderView(saved))
+}
+
+// serverComponentError responds to a request whose component callback
+// failed, with a 404 status code for ErrNotFound and 500 otherwise.
+func serverComponentError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, ErrNotFound) {
+		status = http.StatusNotFound
+	}
+	http.Error(w, http.StatusText(status), status)
+}
@@ -0,0 +1,135 @@
+package htmx_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+type contact struct {
+	ID   int
+	Name string
+}
+
+func newContactEditor(store map[int]contact) htmx.ClickToEdit[contact] {
+	return htmx.ClickToEdit[contact]{
+		URL: func(c contact) string {
+			return fmt.Sprintf("/contacts/%d", c.ID)
+		},
+		View: func(c contact, attrs htmx.ClickToEditAttrs) nodx.Node {
+			return nodx.Div(
+				attrs.Root,
+				nodx.P(nodx.Text(c.Name)),
+				nodx.Button(attrs.Action, nodx.Text("Edit")),
+			)
+		},
+		Edit: func(c contact, attrs htmx.ClickToEditAttrs, errs htmx.ValidationErrors) nodx.Node {
+			return nodx.FormEl(
+				attrs.Root,
+				attrs.Action,
+				nodx.Input(nodx.Name("name"), nodx.Value(c.Name)),
+				nodx.If(errs.Has("name"), nodx.SpanEl(nodx.Text(errs["name"]))),
+				nodx.Button(nodx.Text("Save")),
+				nodx.Button(nodx.Type("button"), attrs.Cancel, nodx.Text("Cancel")),
+			)
+		},
+		Load: func(r *http.Request) (contact, error) {
+			c, ok := store[1]
+			if !ok || !strings.HasPrefix(r.URL.Path, "/contacts/1") {
+				return c, htmx.ErrNotFound
+			}
+			return c, nil
+		},
+		Save: func(r *http.Request, c contact) (contact, error) {
+			c.Name = r.FormValue("name")
+			if c.Name == "" {
+				return c, htmx.ValidationErrors{"name": "is required"}
+			}
+			store[c.ID] = c
+			return c, nil
+		},
+	}
+}
+
+func ExampleClickToEdit() {
+	editor := newContactEditor(nil)
+	fmt.Println(editor.RenderView(contact{ID: 1, Name: "Ada"}))
+	fmt.Println(editor.RenderEdit(contact{ID: 1, Name: "Ada"}, nil))
+	// Output:
+	// <div hx-target="this" hx-swap="outerHTML"><p>Ada</p><button hx-get="/contacts/1/edit">Edit</button></div>
+	// <form hx-target="this" hx-swap="outerHTML" hx-put="/contacts/1"><input name="name" value="Ada"><button>Save</button><button type="button" hx-get="/contacts/1">Cancel</button></form>
+}
+
+func TestClickToEditHandler(t *testing.T) {
+	store := map[int]contact{1: {ID: 1, Name: "Ada"}}
+	editor := newContactEditor(store)
+
+	tests := []struct {
+		method string
+		url    string
+		form   string
+		status int
+		body   string
+	}{
+		{http.MethodGet, "/contacts/1", "", http.StatusOK, "<p>Ada</p>"},
+		{http.MethodGet, "/contacts/1/edit", "", http.StatusOK, `<input name="name" value="Ada">`},
+		{http.MethodGet, "/contacts/2", "", http.StatusNotFound, "Not Found"},
+		{http.MethodPut, "/contacts/1", "name=", http.StatusOK, "<span>is required</span>"},
+		{http.MethodPut, "/contacts/1", "name=Grace", http.StatusOK, "<p>Grace</p>"},
+		{http.MethodPut, "/contacts/1/edit", "", http.StatusMethodNotAllowed, "Method Not Allowed"},
+		{http.MethodDelete, "/contacts/1", "", http.StatusMethodNotAllowed, "Method Not Allowed"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.form))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		editor.ServeHTTP(rec, r)
+
+		if rec.Code != tt.status || !strings.Contains(rec.Body.String(), tt.body) {
+			t.Errorf("%s %s: expected %d containing %q, got %d %q", tt.method, tt.url, tt.status, tt.body, rec.Code, rec.Body.String())
+		}
+	}
+
+	if store[1].Name != "Grace" {
+		t.Errorf("expected the contact to be saved, got %+v", store[1])
+	}
+
+	// Empty ValidationErrors returned as an error are a successful save.
+	var typedNil htmx.ValidationErrors
+	for _, errs := range []htmx.ValidationErrors{typedNil, {}} {
+		editor.Save = func(r *http.Request, c contact) (contact, error) {
+			c.Name = r.FormValue("name")
+			return c, errs
+		}
+
+		r := httptest.NewRequest(http.MethodPut, "/contacts/1", strings.NewReader("name=Ada"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		editor.ServeHTTP(rec, r)
+
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "<p>Ada</p>") {
+			t.Errorf("%#v: expected the view, got %d %q", errs, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestClickToEditAllowHeader(t *testing.T) {
+	editor := newContactEditor(nil)
+
+	rec := httptest.NewRecorder()
+	editor.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/contacts/1", nil))
+	if got := rec.Header().Get("Allow"); got != "GET, PUT" {
+		t.Errorf("Allow: expected %q, got %q", "GET, PUT", got)
+	}
+
+	rec = httptest.NewRecorder()
+	editor.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/contacts/1/edit", nil))
+	if got := rec.Header().Get("Allow"); got != "GET" {
+		t.Errorf("Allow: expected %q, got %q", "GET", got)
+	}
+}
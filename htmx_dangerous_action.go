@@ -0,0 +1,115 @@
+package htmx
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// PromptMismatchError is returned by ServerVerifyPrompt when the answer to an
+// hx-prompt does not match the expected value.
+type PromptMismatchError struct {
+	// Expected is the expected answer.
+	Expected string
+
+	// Answer is the answer sent by the user.
+	Answer string
+}
+
+func (e *PromptMismatchError) Error() string {
+	return fmt.Sprintf("prompt answer %q does not match %q", e.Answer, e.Expected)
+}
+
+// Node renders the error message shown to the user.
+//
+// Output: <p role="alert">Type "[Expected]" to confirm.</p>
+func (e *PromptMismatchError) Node() nodx.Node {
+	return nodx.P(
+		nodx.Role("alert"),
+		nodx.Text("Type \""+e.Expected+"\" to confirm."),
+	)
+}
+
+// ServerVerifyPrompt checks that the "HX-Prompt" request header, trimmed,
+// equals the expected value, returning a *PromptMismatchError otherwise.
+func ServerVerifyPrompt(headers http.Header, expected string) error {
+	answer := strings.TrimSpace(ServerGetPrompt(headers))
+	if answer != expected {
+		return &PromptMismatchError{Expected: expected, Answer: answer}
+	}
+	return nil
+}
+
+// DangerousAction guards a destructive request with a prompt the user must
+// answer with an expected value, such as the name of the project being
+// deleted, verified on the server, and a confirmation dialog.
+//
+// htmx shows the prompt first and the confirmation after it, so the
+// confirmation is the last chance to cancel.
+//
+// https://htmx.org/attributes/hx-prompt/
+type DangerousAction struct {
+	// Confirm, if set, is the confirmation asked before sending the request,
+	// after the prompt if there is one.
+	Confirm string
+
+	// Prompt, if set, is the question asked first, whose answer is verified
+	// by ServerVerify, e.g. "Type the project name to delete it".
+	Prompt string
+
+	// ErrorID is the id of the container of the error message. Defaults to
+	// "dangerous-action-error".
+	ErrorID string
+}
+
+func (a DangerousAction) errorID() string {
+	if a.ErrorID == "" {
+		return "dangerous-action-error"
+	}
+	return a.ErrorID
+}
+
+// Attrs renders the given request attribute, e.g. HxDelete("/projects/1"),
+// along with the confirmation and prompt attributes.
+//
+// Output: [request] hx-confirm="[Confirm]" hx-prompt="[Prompt]"
+func (a DangerousAction) Attrs(request nodx.Node) nodx.Node {
+	return nodx.Group(
+		request,
+		nodx.If(a.Confirm != "", HxConfirm(a.Confirm)),
+		nodx.If(a.Prompt != "", HxPrompt(a.Prompt)),
+	)
+}
+
+// Errors renders the container of the error message with the given initial
+// children.
+//
+// Output: <div id="[ErrorID]" aria-live="assertive">[children]</div>
+func (a DangerousAction) Errors(children ...nodx.Node) nodx.Node {
+	return nodx.Div(
+		nodx.Id(a.errorID()),
+		nodx.Aria("live", "assertive"),
+		nodx.Group(children...),
+	)
+}
+
+// ServerVerify verifies the prompt answer with ServerVerifyPrompt and reports
+// whether the action can proceed.
+//
+// On a mismatch it renders the error message into the Errors container, by
+// setting the "HX-Retarget" and "HX-Reswap" headers, and the handler must
+// return without writing to the response.
+func (a DangerousAction) ServerVerify(w http.ResponseWriter, r *http.Request, expected string) bool {
+	err := ServerVerifyPrompt(r.Header, expected)
+	if err == nil {
+		return true
+	}
+
+	ServerSetRetarget(w.Header(), "#"+a.errorID())
+	ServerSetReswap(w.Header(), "innerHTML")
+	_ = ServerRender(w, err.(*PromptMismatchError).Node())
+
+	return false
+}
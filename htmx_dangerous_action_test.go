@@ -0,0 +1,79 @@
+package htmx_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func ExampleDangerousAction() {
+	action := htmx.DangerousAction{
+		Confirm: "Delete this project?",
+		Prompt:  "Type the project name to delete it",
+	}
+	fmt.Println(nodx.Button(action.Attrs(htmx.HxDelete("/projects/1")), nodx.Text("Delete")))
+	fmt.Println(action.Errors())
+	// Output:
+	// <button hx-delete="/projects/1" hx-confirm="Delete this project?" hx-prompt="Type the project name to delete it">Delete</button>
+	// <div id="dangerous-action-error" aria-live="assertive"></div>
+}
+
+func TestServerVerifyPrompt(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("HX-Prompt", " acme ")
+	if err := htmx.ServerVerifyPrompt(headers, "acme"); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	headers.Set("HX-Prompt", "acme2")
+	err := htmx.ServerVerifyPrompt(headers, "acme")
+	var mismatch *htmx.PromptMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Answer != "acme2" || mismatch.Expected != "acme" {
+		t.Fatalf("expected a *PromptMismatchError, got %v", err)
+	}
+	if got := mismatch.Node().String(); got != `<p role="alert">Type &quot;acme&quot; to confirm.</p>` {
+		t.Errorf("unexpected node %q", got)
+	}
+}
+
+func TestDangerousActionServerVerify(t *testing.T) {
+	action := htmx.DangerousAction{ErrorID: "delete-error"}
+
+	r := httptest.NewRequest(http.MethodDelete, "/projects/1", nil)
+	r.Header.Set("HX-Prompt", "wrong")
+	rec := httptest.NewRecorder()
+	if action.ServerVerify(rec, r, "acme") {
+		t.Fatal("expected the verification to fail")
+	}
+	if got := rec.Header().Get("HX-Retarget"); got != "#delete-error" {
+		t.Errorf("HX-Retarget: expected %q, got %q", "#delete-error", got)
+	}
+	if got := rec.Header().Get("HX-Reswap"); got != "innerHTML" {
+		t.Errorf("HX-Reswap: expected %q, got %q", "innerHTML", got)
+	}
+	if rec.Code != http.StatusOK || rec.Body.Len() == 0 {
+		t.Errorf("expected the error message with a 200 status code, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	r.Header.Set("HX-Prompt", "acme")
+	rec = httptest.NewRecorder()
+	if !action.ServerVerify(rec, r, "acme") {
+		t.Fatal("expected the verification to succeed")
+	}
+	if rec.Body.Len() != 0 || rec.Header().Get("HX-Retarget") != "" {
+		t.Errorf("expected nothing to be written, got %q", rec.Body.String())
+	}
+}
+
+func TestPromptMismatchErrorNodeEscaping(t *testing.T) {
+	err := &htmx.PromptMismatchError{Expected: `O"Brien a\b <x>`}
+	expected := `<p role="alert">Type &quot;O&quot;Brien a\b &lt;x&gt;&quot; to confirm.</p>`
+	if got := err.Node().String(); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
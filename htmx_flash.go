@@ -0,0 +1,358 @@
+package htmx
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// FlashLevel is the severity of a flash message.
+type FlashLevel string
+
+const (
+	FlashInfo    FlashLevel = "info"
+	FlashSuccess FlashLevel = "success"
+	FlashWarning FlashLevel = "warning"
+	FlashError   FlashLevel = "error"
+)
+
+// FlashMessage is a one-time message shown to the user, usually as a toast
+// after a form submission.
+type FlashMessage struct {
+	Level FlashLevel `json:"level"`
+	Text  string     `json:"text"`
+}
+
+// FlashDelivery is how flash messages are delivered on htmx requests.
+type FlashDelivery int
+
+const (
+	// FlashDeliverTrigger delivers the messages as an HX-Trigger event whose
+	// detail is {"messages": [{"level": "…", "text": "…"}, …]}.
+	FlashDeliverTrigger FlashDelivery = iota
+
+	// FlashDeliverOOB delivers the rendered messages with an out of band swap
+	// that appends them to the flash container. Responses without a body,
+	// such as 204 No Content, fall back to FlashDeliverTrigger.
+	FlashDeliverOOB
+)
+
+// FlashStore persists the flash messages that could not be delivered in the
+// current response, e.g. before a redirect, so they can be shown on the next
+// full page load.
+type FlashStore interface {
+	// Load returns the stored messages.
+	Load(r *http.Request) ([]FlashMessage, error)
+
+	// Save stores the messages, replacing any stored before. Saving no
+	// messages clears the store. It is called before the response headers
+	// are written.
+	Save(w http.ResponseWriter, r *http.Request, messages []FlashMessage) error
+}
+
+// flashCookieMaxSize is the maximum size of the flash cookie, attributes
+// included. Browsers silently drop bigger cookies.
+const flashCookieMaxSize = 4096
+
+// FlashCookieStore is a FlashStore that keeps the messages in a cookie.
+//
+// Browsers limit cookies to about 4 KB, so when the messages don't fit the
+// oldest ones are dropped.
+type FlashCookieStore struct {
+	// Name is the cookie name. Defaults to "flash".
+	Name string
+
+	// Path is the cookie path. Defaults to "/".
+	Path string
+
+	// Secure restricts the cookie to HTTPS.
+	Secure bool
+}
+
+func (s FlashCookieStore) name() string {
+	if s.Name == "" {
+		return "flash"
+	}
+	return s.Name
+}
+
+func (s FlashCookieStore) path() string {
+	if s.Path == "" {
+		return "/"
+	}
+	return s.Path
+}
+
+// Load implements FlashStore.
+func (s FlashCookieStore) Load(r *http.Request) ([]FlashMessage, error) {
+	cookie, err := r.Cookie(s.name())
+	if err == http.ErrNoCookie {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read flash cookie: %w", err)
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode flash cookie: %w", err)
+	}
+
+	var messages []FlashMessage
+	if err := json.Unmarshal(b, &messages); err != nil {
+		return nil, fmt.Errorf("failed to decode flash cookie: %w", err)
+	}
+
+	return messages, nil
+}
+
+// Save implements FlashStore.
+//
+// It keeps the most recent messages that fit in the cookie, and returns an
+// error, clearing the cookie, if not even the last one fits.
+func (s FlashCookieStore) Save(w http.ResponseWriter, r *http.Request, messages []FlashMessage) error {
+	cookie := &http.Cookie{
+		Name:     s.name(),
+		Path:     s.path(),
+		Secure:   s.Secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+
+	tooLarge := len(messages) > 0
+	for len(messages) > 0 {
+		b, err := json.Marshal(messages)
+		if err != nil {
+			return fmt.Errorf("failed to encode flash cookie: %w", err)
+		}
+		cookie.Value = base64.RawURLEncoding.EncodeToString(b)
+		if len(cookie.String()) <= flashCookieMaxSize {
+			http.SetCookie(w, cookie)
+			return nil
+		}
+		messages = messages[1:]
+	}
+
+	cookie.Value = ""
+	cookie.MaxAge = -1
+	http.SetCookie(w, cookie)
+
+	if tooLarge {
+		return errors.New("flash message too large for a cookie")
+	}
+	return nil
+}
+
+// FlashConfig configures FlashMiddleware.
+type FlashConfig struct {
+	// Store persists the undelivered messages. Defaults to FlashCookieStore{}.
+	Store FlashStore
+
+	// Delivery is how the messages are delivered on htmx requests. Defaults
+	// to FlashDeliverTrigger.
+	Delivery FlashDelivery
+
+	// EventName is the name of the event used by FlashDeliverTrigger.
+	// Defaults to "flash".
+	EventName string
+
+	// ContainerID is the id of the element rendered by FlashContainer where
+	// FlashDeliverOOB appends the messages. Defaults to "flash-messages".
+	ContainerID string
+
+	// Render renders a single message. Defaults to a div with the
+	// "flash flash-[level]" classes.
+	Render func(message FlashMessage) nodx.Node
+}
+
+func (c FlashConfig) withDefaults() FlashConfig {
+	if c.Store == nil {
+		c.Store = FlashCookieStore{}
+	}
+	if c.EventName == "" {
+		c.EventName = "flash"
+	}
+	if c.ContainerID == "" {
+		c.ContainerID = "flash-messages"
+	}
+	if c.Render == nil {
+		c.Render = renderFlashMessage
+	}
+	return c
+}
+
+// renderFlashMessage is the default FlashConfig.Render.
+func renderFlashMessage(message FlashMessage) nodx.Node {
+	role := "status"
+	if message.Level == FlashError {
+		role = "alert"
+	}
+	return nodx.Div(
+		nodx.Class("flash flash-"+string(message.Level)),
+		nodx.Role(role),
+		nodx.Text(message.Text),
+	)
+}
+
+// flashState holds the flash messages of a request.
+type flashState struct {
+	config FlashConfig
+
+	mu sync.Mutex
+	// pending are the stored and queued messages not shown yet.
+	pending []FlashMessage
+	// stored indicates whether the store had messages when loaded.
+	stored bool
+}
+
+// take returns the pending messages and clears them.
+func (s *flashState) take() []FlashMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	messages := s.pending
+	s.pending = nil
+	return messages
+}
+
+// FlashMiddleware installs the flash message system.
+//
+// Handlers queue messages with ServerFlash. Before the response headers are
+// written the pending messages are:
+//
+//   - Delivered according to FlashConfig.Delivery on htmx requests that are
+//     not redirected with HX-Redirect, HX-Location or HX-Refresh.
+//   - Saved to the FlashStore otherwise, so the layout of the next full page
+//     load can render them with FlashContainer or ServerFlashes.
+//
+// It installs ResponseMiddleware if it is not installed yet.
+func FlashMiddleware(config FlashConfig) func(http.Handler) http.Handler {
+	config = config.withDefaults()
+
+	return func(next http.Handler) http.Handler {
+		return ResponseMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			state := &flashState{config: config}
+			messages, err := config.Store.Load(r)
+			if err != nil {
+				// Clear the invalid store so the error doesn't repeat on
+				// every request.
+				_ = config.Store.Save(w, r, nil)
+			} else if len(messages) > 0 {
+				state.pending = messages
+				state.stored = true
+			}
+
+			r = r.WithContext(context.WithValue(r.Context(), flashContextKey, state))
+			res, _ := ServerResponse(r)
+			res.onBeforeHeaders(func(w http.ResponseWriter, status int) {
+				deliverFlashes(w, r, res, state, status)
+			})
+
+			next.ServeHTTP(w, r)
+		}))
+	}
+}
+
+// deliverFlashes delivers or stores the pending messages right before the
+// response headers are written.
+func deliverFlashes(w http.ResponseWriter, r *http.Request, res *Response, state *flashState, status int) {
+	messages := state.take()
+	config := state.config
+
+	if len(messages) == 0 {
+		if state.stored {
+			_ = config.Store.Save(w, r, nil)
+		}
+		return
+	}
+
+	headers := w.Header()
+	redirected := status >= 300 && status < 400 ||
+		headers.Get("HX-Redirect") != "" ||
+		headers.Get("HX-Location") != "" ||
+		headers.Get("HX-Refresh") == "true"
+	deliverable := ServerGetIsHtmxRequest(r.Header) &&
+		!ServerGetIsHistoryRestoreRequest(r.Header) &&
+		!redirected && status < 400
+
+	if !deliverable {
+		_ = config.Store.Save(w, r, messages)
+		return
+	}
+
+	// Out of band fragments are dropped from responses without a body, so
+	// those get the messages as an event instead.
+	delivery := config.Delivery
+	if delivery == FlashDeliverOOB && !responseHasOOB(r, status) {
+		delivery = FlashDeliverTrigger
+	}
+
+	switch delivery {
+	case FlashDeliverOOB:
+		res.AddOOB(nodx.Div(
+			HxSwapOOB("beforeend:#"+config.ContainerID),
+			nodx.Map(messages, config.Render),
+		))
+	default:
+		if err := res.AddTrigger(config.EventName, map[string]any{"messages": messages}); err != nil {
+			_ = config.Store.Save(w, r, messages)
+			return
+		}
+	}
+
+	if state.stored {
+		_ = config.Store.Save(w, r, nil)
+	}
+}
+
+// ServerFlash queues a flash message for the user.
+//
+// It does nothing if FlashMiddleware is not installed.
+func ServerFlash(r *http.Request, level FlashLevel, text string) {
+	state, ok := r.Context().Value(flashContextKey).(*flashState)
+	if !ok {
+		return
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.pending = append(state.pending, FlashMessage{Level: level, Text: text})
+}
+
+// ServerFlashes returns the pending flash messages and marks them as shown,
+// so they are neither delivered again nor kept in the store.
+//
+// Use it in layouts that render the messages themselves, otherwise use
+// FlashContainer. It returns nil if FlashMiddleware is not installed.
+func ServerFlashes(r *http.Request) []FlashMessage {
+	state, ok := r.Context().Value(flashContextKey).(*flashState)
+	if !ok {
+		return nil
+	}
+	return state.take()
+}
+
+// FlashContainer renders the flash messages container with the pending
+// messages, marking them as shown.
+//
+// It must be rendered in the layout for FlashDeliverOOB to have a target.
+// Outside FlashMiddleware it renders the empty default container.
+//
+// Output: <div id="[ContainerID]" aria-live="polite">[messages]</div>
+func FlashContainer(r *http.Request) nodx.Node {
+	config := FlashConfig{}.withDefaults()
+	if state, ok := r.Context().Value(flashContextKey).(*flashState); ok {
+		config = state.config
+	}
+
+	return nodx.Div(
+		nodx.Id(config.ContainerID),
+		nodx.Aria("live", "polite"),
+		nodx.Map(ServerFlashes(r), config.Render),
+	)
+}
@@ -0,0 +1,199 @@
+package htmx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFlashMiddlewareTrigger(t *testing.T) {
+	handler := FlashMiddleware(FlashConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServerFlash(r, FlashSuccess, "Saved")
+		_, _ = w.Write([]byte("<p>ok</p>"))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newHtmxRequest(http.MethodPost, "/"))
+
+	expected := `{"flash":{"messages":[{"level":"success","text":"Saved"}]}}`
+	if got := rec.Header().Get("HX-Trigger"); got != expected {
+		t.Errorf("HX-Trigger: expected %q, got %q", expected, got)
+	}
+	if got := rec.Header().Get("Set-Cookie"); got != "" {
+		t.Errorf("Set-Cookie: expected empty, got %q", got)
+	}
+}
+
+func TestFlashMiddlewareOOB(t *testing.T) {
+	config := FlashConfig{Delivery: FlashDeliverOOB, ContainerID: "toasts"}
+	handler := FlashMiddleware(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServerFlash(r, FlashError, "Failed")
+		_, _ = w.Write([]byte("<p>ok</p>"))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newHtmxRequest(http.MethodPost, "/"))
+
+	expected := `<p>ok</p><div hx-swap-oob="beforeend:#toasts"><div class="flash flash-error" role="alert">Failed</div></div>`
+	if got := rec.Body.String(); got != expected {
+		t.Errorf("body: expected %q, got %q", expected, got)
+	}
+}
+
+func TestFlashMiddlewareOOBNoContent(t *testing.T) {
+	config := FlashConfig{Delivery: FlashDeliverOOB}
+	handler := FlashMiddleware(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServerFlash(r, FlashSuccess, "Deleted")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newHtmxRequest(http.MethodDelete, "/"))
+
+	if rec.Body.Len() != 0 {
+		t.Errorf("body: expected empty, got %q", rec.Body.String())
+	}
+	expected := `{"flash":{"messages":[{"level":"success","text":"Deleted"}]}}`
+	if got := rec.Header().Get("HX-Trigger"); got != expected {
+		t.Errorf("HX-Trigger: expected the messages as an event %q, got %q", expected, got)
+	}
+}
+
+func TestFlashMiddlewareRedirectRoundTrip(t *testing.T) {
+	handler := FlashMiddleware(FlashConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/save" {
+			ServerFlash(r, FlashInfo, "Created")
+			http.Redirect(w, r, "/", http.StatusSeeOther)
+			return
+		}
+		_, _ = w.Write([]byte(FlashContainer(r).String()))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/save", nil))
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != "flash" || cookies[0].Value == "" {
+		t.Fatalf("expected a flash cookie, got %v", cookies)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(cookies[0])
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+
+	expected := `<div id="flash-messages" aria-live="polite"><div class="flash flash-info" role="status">Created</div></div>`
+	if got := rec.Body.String(); got != expected {
+		t.Errorf("body: expected %q, got %q", expected, got)
+	}
+	cookies = rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].MaxAge != -1 {
+		t.Errorf("expected the flash cookie to be cleared, got %v", cookies)
+	}
+}
+
+func TestFlashMiddlewareHxRedirect(t *testing.T) {
+	handler := FlashMiddleware(FlashConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServerFlash(r, FlashInfo, "Moved")
+		ServerSetRedirect(w.Header(), "/elsewhere")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newHtmxRequest(http.MethodPost, "/"))
+
+	if got := rec.Header().Get("HX-Trigger"); got != "" {
+		t.Errorf("HX-Trigger: expected empty, got %q", got)
+	}
+	if !strings.HasPrefix(rec.Header().Get("Set-Cookie"), "flash=") {
+		t.Errorf("Set-Cookie: expected flash cookie, got %q", rec.Header().Get("Set-Cookie"))
+	}
+}
+
+func TestFlashMiddlewareUnshownFullPage(t *testing.T) {
+	handler := FlashMiddleware(FlashConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServerFlash(r, FlashWarning, "Later")
+		_, _ = w.Write([]byte("<html></html>"))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if !strings.HasPrefix(rec.Header().Get("Set-Cookie"), "flash=") {
+		t.Errorf("Set-Cookie: expected the messages to be kept, got %q", rec.Header().Get("Set-Cookie"))
+	}
+}
+
+func TestServerFlashWithoutMiddleware(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	ServerFlash(r, FlashInfo, "ignored")
+	if messages := ServerFlashes(r); messages != nil {
+		t.Errorf("expected no messages, got %v", messages)
+	}
+
+	expected := `<div id="flash-messages" aria-live="polite"></div>`
+	if got := FlashContainer(r).String(); got != expected {
+		t.Errorf("FlashContainer: expected %q, got %q", expected, got)
+	}
+}
+
+func TestFlashCookieStoreInvalidCookie(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: "flash", Value: "%%%"})
+	if _, err := (FlashCookieStore{}).Load(r); err == nil {
+		t.Error("Expected an error for an invalid cookie")
+	}
+}
+
+func TestFlashMiddlewareClearsInvalidCookie(t *testing.T) {
+	handler := FlashMiddleware(FlashConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<p>ok</p>"))
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: "flash", Value: "%%%"})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != "flash" || cookies[0].MaxAge >= 0 {
+		t.Errorf("expected the invalid flash cookie to be cleared, got %v", cookies)
+	}
+}
+
+func TestFlashCookieStoreSizeLimit(t *testing.T) {
+	store := FlashCookieStore{}
+	long := strings.Repeat("a", 1000)
+	messages := []FlashMessage{
+		{Level: FlashInfo, Text: "first " + long},
+		{Level: FlashInfo, Text: "second " + long},
+		{Level: FlashInfo, Text: "third " + long},
+		{Level: FlashInfo, Text: "last"},
+	}
+
+	rec := httptest.NewRecorder()
+	if err := store.Save(rec, httptest.NewRequest(http.MethodGet, "/", nil), messages); err != nil {
+		t.Fatal(err)
+	}
+	header := rec.Header().Get("Set-Cookie")
+	if len(header) > flashCookieMaxSize {
+		t.Fatalf("expected the cookie to fit in %d bytes, got %d", flashCookieMaxSize, len(header))
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Cookie", strings.SplitN(header, ";", 2)[0])
+	loaded, err := store.Load(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 3 || !strings.HasPrefix(loaded[0].Text, "second") || loaded[2].Text != "last" {
+		t.Errorf("expected the oldest message to be dropped, got %d messages", len(loaded))
+	}
+
+	rec = httptest.NewRecorder()
+	err = store.Save(rec, httptest.NewRequest(http.MethodGet, "/", nil), []FlashMessage{{Text: strings.Repeat("a", 5000)}})
+	if err == nil {
+		t.Error("expected an error for a message that doesn't fit")
+	}
+	if cookies := rec.Result().Cookies(); len(cookies) != 1 || cookies[0].MaxAge >= 0 {
+		t.Errorf("expected the cookie to be cleared, got %v", cookies)
+	}
+}
@@ -0,0 +1,323 @@
+package htmx
+
+import (
+	"bytes"
+	"container/list"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// FragmentKey identifies a cached fragment.
+type FragmentKey struct {
+	// Route is the request route, usually the path and query.
+	Route string
+
+	// Target is the id of the target element, see ServerGetTarget.
+	Target string
+
+	// Keys are user provided values the fragment depends on, e.g. the user
+	// id or the locale.
+	Keys []string
+}
+
+// String returns an unambiguous representation of the key.
+func (k FragmentKey) String() string {
+	parts := make([]string, 0, len(k.Keys)+2)
+	parts = append(parts, strconv.Quote(k.Route), strconv.Quote(k.Target))
+	for _, key := range k.Keys {
+		parts = append(parts, strconv.Quote(key))
+	}
+	return strings.Join(parts, " ")
+}
+
+// ServerFragmentKey returns the FragmentKey of the request, made of its path
+// and query, its HX-Target header and the given keys.
+func ServerFragmentKey(r *http.Request, keys ...string) FragmentKey {
+	return FragmentKey{
+		Route:  r.URL.RequestURI(),
+		Target: ServerGetTarget(r.Header),
+		Keys:   keys,
+	}
+}
+
+// FragmentCacheConfig configures a FragmentCache.
+type FragmentCacheConfig struct {
+	// TTL is how long a fragment is kept. Defaults to one minute.
+	TTL time.Duration
+
+	// MaxBytes is the maximum total size of the cached fragments. The least
+	// recently used fragments are evicted when it is exceeded, and fragments
+	// bigger than it are never cached. Defaults to 16 MiB.
+	MaxBytes int
+}
+
+// FragmentCache is an in-memory cache of rendered fragments, bounded by time
+// and size, for expensive partials that are requested constantly, e.g. by
+// polling triggers.
+//
+// Concurrent requests for the same missing fragment are deduplicated so it
+// is rendered only once. It is safe for concurrent use.
+type FragmentCache struct {
+	config FragmentCacheConfig
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	tags    map[string]map[string]struct{}
+	size    int
+	calls   map[string]*fragmentCall
+	// generation is incremented by every invalidation so renders that were
+	// in flight during one are not cached.
+	generation uint64
+}
+
+// fragmentEntry is a cached fragment.
+type fragmentEntry struct {
+	key       string
+	content   []byte
+	tags      []string
+	expiresAt time.Time
+}
+
+// fragmentCall is an in-flight render shared by concurrent requests.
+type fragmentCall struct {
+	done    chan struct{}
+	content []byte
+	err     error
+}
+
+// NewFragmentCache creates a FragmentCache.
+func NewFragmentCache(config FragmentCacheConfig) *FragmentCache {
+	if config.TTL <= 0 {
+		config.TTL = time.Minute
+	}
+	if config.MaxBytes <= 0 {
+		config.MaxBytes = 16 << 20
+	}
+
+	return &FragmentCache{
+		config:  config,
+		now:     time.Now,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+		tags:    map[string]map[string]struct{}{},
+		calls:   map[string]*fragmentCall{},
+	}
+}
+
+// Fetch returns the rendered fragment for the key, calling render and caching
+// its output, associated with the given tags, if it is missing or expired.
+//
+// The returned bytes are shared and must not be modified.
+func (c *FragmentCache) Fetch(key FragmentKey, tags []string, render func() nodx.Node) ([]byte, error) {
+	k := key.String()
+
+	c.mu.Lock()
+	if content, ok := c.get(k); ok {
+		c.mu.Unlock()
+		return content, nil
+	}
+	if call, ok := c.calls[k]; ok {
+		c.mu.Unlock()
+		<-call.done
+		return call.content, call.err
+	}
+	call := &fragmentCall{done: make(chan struct{})}
+	c.calls[k] = call
+	generation := c.generation
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.calls, k)
+		if call.err == nil && generation == c.generation {
+			c.set(k, call.content, tags)
+		}
+		c.mu.Unlock()
+		close(call.done)
+	}()
+
+	// Waiters get this error if render panics.
+	call.err = errors.New("fragment render panicked")
+	call.content, call.err = renderFragment(render)
+
+	return call.content, call.err
+}
+
+// renderFragment renders the node returned by render to bytes.
+func renderFragment(render func() nodx.Node) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := render().Render(buf); err != nil {
+		return nil, fmt.Errorf("failed to render fragment: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Node returns a node that renders the cached fragment, see Fetch.
+//
+// The fragment is fetched when the node is rendered, so it can be used
+// anywhere inside a larger page.
+func (c *FragmentCache) Node(key FragmentKey, tags []string, render func() nodx.Node) nodx.Node {
+	return cachedFragmentNode{cache: c, key: key, tags: tags, render: render}
+}
+
+// RenderPartial is the cached version of ServerRenderPartial: full pages are
+// rendered as usual while fragments are served from the cache.
+func (c *FragmentCache) RenderPartial(
+	w http.ResponseWriter,
+	r *http.Request,
+	key FragmentKey,
+	tags []string,
+	page func() nodx.Node,
+	fragment func() nodx.Node,
+) error {
+	if ServerWantsFullPage(r.Header) {
+		return ServerRender(w, page())
+	}
+	return ServerRender(w, c.Node(key, tags, fragment))
+}
+
+// Invalidate removes the fragment with the given key.
+func (c *FragmentCache) Invalidate(key FragmentKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	if elem, ok := c.entries[key.String()]; ok {
+		c.remove(elem)
+	}
+}
+
+// InvalidateTags removes every fragment associated with any of the tags.
+func (c *FragmentCache) InvalidateTags(tags ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for _, tag := range tags {
+		for k := range c.tags[tag] {
+			if elem, ok := c.entries[k]; ok {
+				c.remove(elem)
+			}
+		}
+	}
+}
+
+// Len returns the number of cached fragments, including expired ones not
+// evicted yet.
+func (c *FragmentCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// get returns the cached content of a key. The lock must be held.
+func (c *FragmentCache) get(k string) ([]byte, bool) {
+	elem, ok := c.entries[k]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*fragmentEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return entry.content, true
+}
+
+// set caches the content of a key, evicting the least recently used
+// fragments if needed. The lock must be held.
+func (c *FragmentCache) set(k string, content []byte, tags []string) {
+	if elem, ok := c.entries[k]; ok {
+		c.remove(elem)
+	}
+	if len(content) > c.config.MaxBytes {
+		return
+	}
+
+	for c.size+len(content) > c.config.MaxBytes {
+		c.remove(c.lru.Back())
+	}
+
+	entry := &fragmentEntry{
+		key:       k,
+		content:   content,
+		tags:      append([]string(nil), tags...),
+		expiresAt: c.now().Add(c.config.TTL),
+	}
+	c.entries[k] = c.lru.PushFront(entry)
+	c.size += len(content)
+	for _, tag := range entry.tags {
+		if c.tags[tag] == nil {
+			c.tags[tag] = map[string]struct{}{}
+		}
+		c.tags[tag][k] = struct{}{}
+	}
+}
+
+// remove removes a cached fragment. The lock must be held.
+func (c *FragmentCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*fragmentEntry)
+	delete(c.entries, entry.key)
+	c.size -= len(entry.content)
+	for _, tag := range entry.tags {
+		delete(c.tags[tag], entry.key)
+		if len(c.tags[tag]) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+}
+
+// ensure that cachedFragmentNode implements the nodx.Node interface.
+var _ nodx.Node = cachedFragmentNode{}
+
+// cachedFragmentNode is the node returned by FragmentCache.Node.
+type cachedFragmentNode struct {
+	cache  *FragmentCache
+	key    FragmentKey
+	tags   []string
+	render func() nodx.Node
+}
+
+func (n cachedFragmentNode) Render(w io.Writer) error {
+	content, err := n.cache.Fetch(n.key, n.tags, n.render)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(content); err != nil {
+		return fmt.Errorf("failed to render cached fragment: %w", err)
+	}
+	return nil
+}
+
+func (n cachedFragmentNode) RenderString() (string, error) {
+	content, err := n.cache.Fetch(n.key, n.tags, n.render)
+	return string(content), err
+}
+
+func (n cachedFragmentNode) RenderBytes() ([]byte, error) {
+	return n.cache.Fetch(n.key, n.tags, n.render)
+}
+
+func (n cachedFragmentNode) IsElement() bool {
+	return true
+}
+
+func (n cachedFragmentNode) IsAttribute() bool {
+	return false
+}
+
+func (n cachedFragmentNode) String() string {
+	str, _ := n.RenderString()
+	return str
+}
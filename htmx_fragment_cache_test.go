@@ -0,0 +1,185 @@
+package htmx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+func TestFragmentCacheFetch(t *testing.T) {
+	cache := NewFragmentCache(FragmentCacheConfig{})
+	key := FragmentKey{Route: "/badge"}
+	renders := 0
+	render := func() nodx.Node {
+		renders++
+		return nodx.SpanEl(nodx.Textf("%d", renders))
+	}
+
+	for i := 0; i < 3; i++ {
+		content, err := cache.Fetch(key, nil, render)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != "<span>1</span>" {
+			t.Errorf("expected cached content, got %q", content)
+		}
+	}
+	if renders != 1 {
+		t.Errorf("expected 1 render, got %d", renders)
+	}
+}
+
+func TestFragmentCacheTTL(t *testing.T) {
+	cache := NewFragmentCache(FragmentCacheConfig{TTL: time.Second})
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	renders := 0
+	render := func() nodx.Node {
+		renders++
+		return nodx.Text("x")
+	}
+	key := FragmentKey{Route: "/"}
+
+	_, _ = cache.Fetch(key, nil, render)
+	now = now.Add(999 * time.Millisecond)
+	_, _ = cache.Fetch(key, nil, render)
+	now = now.Add(time.Millisecond)
+	_, _ = cache.Fetch(key, nil, render)
+
+	if renders != 2 {
+		t.Errorf("expected 2 renders, got %d", renders)
+	}
+}
+
+func TestFragmentCacheMaxBytes(t *testing.T) {
+	cache := NewFragmentCache(FragmentCacheConfig{MaxBytes: 10})
+	render := func(s string) func() nodx.Node {
+		return func() nodx.Node { return nodx.Text(s) }
+	}
+
+	_, _ = cache.Fetch(FragmentKey{Route: "a"}, nil, render("aaaa"))
+	_, _ = cache.Fetch(FragmentKey{Route: "b"}, nil, render("bbbb"))
+	_, _ = cache.Fetch(FragmentKey{Route: "a"}, nil, render("aaaa"))
+	_, _ = cache.Fetch(FragmentKey{Route: "c"}, nil, render("cccc"))
+
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 fragments, got %d", cache.Len())
+	}
+	if _, ok := cache.entries[FragmentKey{Route: "b"}.String()]; ok {
+		t.Error("expected the least recently used fragment to be evicted")
+	}
+
+	_, _ = cache.Fetch(FragmentKey{Route: "big"}, nil, render(strings.Repeat("x", 11)))
+	if cache.Len() != 2 {
+		t.Errorf("expected fragments bigger than MaxBytes not to be cached, got %d", cache.Len())
+	}
+}
+
+func TestFragmentCacheInvalidateTags(t *testing.T) {
+	cache := NewFragmentCache(FragmentCacheConfig{})
+	render := func() nodx.Node { return nodx.Text("x") }
+
+	_, _ = cache.Fetch(FragmentKey{Route: "/a"}, []string{"users"}, render)
+	_, _ = cache.Fetch(FragmentKey{Route: "/b"}, []string{"users", "orders"}, render)
+	_, _ = cache.Fetch(FragmentKey{Route: "/c"}, []string{"orders"}, render)
+
+	cache.InvalidateTags("users")
+	if cache.Len() != 1 {
+		t.Errorf("expected 1 fragment, got %d", cache.Len())
+	}
+
+	cache.Invalidate(FragmentKey{Route: "/c"})
+	if cache.Len() != 0 {
+		t.Errorf("expected 0 fragments, got %d", cache.Len())
+	}
+	if len(cache.tags) != 0 {
+		t.Errorf("expected tags index to be empty, got %v", cache.tags)
+	}
+}
+
+func TestFragmentCacheSingleflight(t *testing.T) {
+	cache := NewFragmentCache(FragmentCacheConfig{})
+	var renders atomic.Int32
+	release := make(chan struct{})
+	render := func() nodx.Node {
+		renders.Add(1)
+		<-release
+		return nodx.Text("slow")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			content, err := cache.Fetch(FragmentKey{Route: "/slow"}, nil, render)
+			if err != nil || string(content) != "slow" {
+				t.Errorf("unexpected result %q %v", content, err)
+			}
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := renders.Load(); got != 1 {
+		t.Errorf("expected 1 render, got %d", got)
+	}
+}
+
+func TestFragmentCacheInvalidateDuringRender(t *testing.T) {
+	cache := NewFragmentCache(FragmentCacheConfig{})
+	render := func() nodx.Node {
+		cache.InvalidateTags("users")
+		return nodx.Text("stale")
+	}
+
+	_, _ = cache.Fetch(FragmentKey{Route: "/"}, []string{"users"}, render)
+	if cache.Len() != 0 {
+		t.Error("expected a fragment invalidated while rendering not to be cached")
+	}
+}
+
+func TestFragmentCacheRenderPartial(t *testing.T) {
+	cache := NewFragmentCache(FragmentCacheConfig{})
+	page := func() nodx.Node { return nodx.Text("page") }
+	fragment := func() nodx.Node { return nodx.Text("fragment") }
+
+	r := newHtmxRequest(http.MethodGet, "/nav?tab=1")
+	r.Header.Set("HX-Target", "nav")
+	key := ServerFragmentKey(r, "user-1")
+	if key.Route != "/nav?tab=1" || key.Target != "nav" || key.Keys[0] != "user-1" {
+		t.Errorf("unexpected key %+v", key)
+	}
+
+	rec := httptest.NewRecorder()
+	if err := cache.RenderPartial(rec, r, key, nil, page, fragment); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Body.String() != "fragment" || cache.Len() != 1 {
+		t.Errorf("expected cached fragment, got %q", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/nav?tab=1", nil)
+	_ = cache.RenderPartial(rec, r, ServerFragmentKey(r), nil, page, fragment)
+	if rec.Body.String() != "page" {
+		t.Errorf("expected page, got %q", rec.Body.String())
+	}
+}
+
+func TestFragmentKeyString(t *testing.T) {
+	a := FragmentKey{Route: "/a b", Keys: []string{"c"}}
+	b := FragmentKey{Route: "/a", Target: "b c"}
+	if a.String() == b.String() {
+		t.Errorf("expected different keys, got %q", a.String())
+	}
+}
@@ -0,0 +1,141 @@
+package htmx
+
+import (
+	"net/http"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// ExtensionHeadSupport is the name of the head-support extension, which
+// updates the <head> element on boosted navigation. Enable it with
+// HxExt(ExtensionHeadSupport) on the <body> element.
+//
+// https://htmx.org/extensions/head-support/
+const ExtensionHeadSupport = "head-support"
+
+// HeadMode is how the head-support extension processes a <head> element or
+// one of its children.
+type HeadMode string
+
+const (
+	// HeadMerge, on a <head> element, adds the new elements and removes the
+	// current ones missing from it, except those with hx-preserve="true".
+	// This is the default for boosted requests.
+	HeadMerge HeadMode = "merge"
+
+	// HeadAppend, on a <head> element, adds the new elements and keeps the
+	// current ones.
+	HeadAppend HeadMode = "append"
+
+	// HeadReEval, on a child of a <head> element, re-adds it, e.g. to run a
+	// script again, even if it is already present.
+	HeadReEval HeadMode = "re-eval"
+)
+
+// HxHeadMode renders an hx-head="merge|append|re-eval" attribute.
+//
+// Sets how the head-support extension processes a <head> element or one of
+// its children. Use HxHead to render any other value.
+//
+// https://htmx.org/extensions/head-support/
+func HxHeadMode(mode HeadMode) nodx.Node {
+	return HxHead(string(mode))
+}
+
+// PageHead is the part of the <head> element specific to a page.
+type PageHead struct {
+	// Title is the page title.
+	Title string
+
+	// Meta are <meta> elements, e.g. the page description.
+	Meta []nodx.Node
+
+	// Stylesheets are the URLs of the page stylesheets.
+	Stylesheets []string
+}
+
+// Node renders the head elements of the page.
+//
+// Output: <title>[Title]</title>[Meta]<link rel="stylesheet" href="[Stylesheet]">…
+func (h PageHead) Node() nodx.Node {
+	return nodx.Group(
+		nodx.If(h.Title != "", nodx.TitleEl(nodx.Text(h.Title))),
+		nodx.Group(h.Meta...),
+		nodx.Map(h.Stylesheets, func(href string) nodx.Node {
+			return nodx.Link(nodx.Rel("stylesheet"), nodx.Href(href))
+		}),
+	)
+}
+
+// Layout renders full documents for regular requests and minimal ones for
+// boosted requests, whose <head> only carries the page head, so boosted
+// navigation keeps the title, meta and stylesheets of every page correct
+// with the head-support extension.
+//
+// https://htmx.org/extensions/head-support/
+type Layout struct {
+	// Lang, if set, is the language of the document.
+	Lang string
+
+	// Head are the head elements shared by every page, such as the htmx
+	// script and the site stylesheets. They are only rendered in full
+	// documents, so they must carry HxPreserve("true") for boosted
+	// navigation to keep them.
+	Head []nodx.Node
+
+	// Body renders the children of the <body> element around the page
+	// content, e.g. the navigation. Defaults to the content alone.
+	Body func(content nodx.Node) nodx.Node
+}
+
+func (l Layout) body(content nodx.Node) nodx.Node {
+	if l.Body == nil {
+		return content
+	}
+	return l.Body(content)
+}
+
+// Render renders the document of the page with the given head and content.
+//
+// Boosted requests, detected with ServerGetIsBoosted, get a minimal document
+// without the shared head elements. Other requests get the full document,
+// whose <body> enables the head-support extension.
+//
+// Output: <!DOCTYPE html><html lang="[Lang]"><head hx-head="merge"><meta charset="utf-8">[Head][page head]</head><body hx-ext="head-support">[Body(content)]</body></html>
+func (l Layout) Render(r *http.Request, head PageHead, content nodx.Node) nodx.Node {
+	if ServerGetIsBoosted(r.Header) {
+		return nodx.Group(
+			nodx.DocType(),
+			nodx.Html(
+				nodx.Head(
+					HxHeadMode(HeadMerge),
+					nodx.Meta(nodx.Charset("utf-8")),
+					head.Node(),
+				),
+				nodx.Body(l.body(content)),
+			),
+		)
+	}
+
+	return nodx.Group(
+		nodx.DocType(),
+		nodx.Html(
+			nodx.If(l.Lang != "", nodx.Lang(l.Lang)),
+			nodx.Head(
+				HxHeadMode(HeadMerge),
+				nodx.Meta(nodx.Charset("utf-8")),
+				nodx.Group(l.Head...),
+				head.Node(),
+			),
+			nodx.Body(
+				HxExt(ExtensionHeadSupport),
+				l.body(content),
+			),
+		),
+	)
+}
+
+// ServerRender renders the document of the page, see Render.
+func (l Layout) ServerRender(w http.ResponseWriter, r *http.Request, head PageHead, content nodx.Node) error {
+	return ServerRender(w, l.Render(r, head, content))
+}
@@ -0,0 +1,70 @@
+package htmx_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+var siteLayout = htmx.Layout{
+	Lang: "en",
+	Head: []nodx.Node{
+		nodx.Script(nodx.Src("/htmx.js"), htmx.HxPreserve("true")),
+	},
+	Body: func(content nodx.Node) nodx.Node {
+		return nodx.Main(content)
+	},
+}
+
+var aboutHead = htmx.PageHead{
+	Title:       "About",
+	Meta:        []nodx.Node{nodx.Meta(nodx.Name("description"), nodx.Content("About us"))},
+	Stylesheets: []string{"/about.css"},
+}
+
+func ExampleHxHeadMode() {
+	fmt.Println(htmx.HxHeadMode(htmx.HeadReEval))
+	// Output: hx-head="re-eval"
+}
+
+func ExampleLayout() {
+	r := httptest.NewRequest(http.MethodGet, "/about", nil)
+	fmt.Println(siteLayout.Render(r, aboutHead, nodx.P(nodx.Text("Hi"))))
+
+	r.Header.Set("HX-Request", "true")
+	r.Header.Set("HX-Boosted", "true")
+	fmt.Println(siteLayout.Render(r, aboutHead, nodx.P(nodx.Text("Hi"))))
+	// Output:
+	// <!DOCTYPE html><html lang="en"><head hx-head="merge"><meta charset="utf-8"><script src="/htmx.js" hx-preserve="true"></script><title>About</title><meta name="description" content="About us"><link rel="stylesheet" href="/about.css"></head><body hx-ext="head-support"><main><p>Hi</p></main></body></html>
+	// <!DOCTYPE html><html><head hx-head="merge"><meta charset="utf-8"><title>About</title><meta name="description" content="About us"><link rel="stylesheet" href="/about.css"></head><body><main><p>Hi</p></main></body></html>
+}
+
+func TestLayoutServerRender(t *testing.T) {
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/about", nil)
+	if err := (htmx.Layout{}).ServerRender(rec, r, htmx.PageHead{}, nodx.Text("Hi")); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<!DOCTYPE html><html><head hx-head="merge"><meta charset="utf-8"></head><body hx-ext="head-support">Hi</body></html>`
+	if got := rec.Body.String(); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "text/html; charset=utf-8" {
+		t.Errorf("Content-Type: unexpected value %q", got)
+	}
+}
+
+func TestHeadAttributeRegistered(t *testing.T) {
+	spec, ok := htmx.LookupAttribute(htmx.AttributeHead)
+	if !ok {
+		t.Fatal("expected hx-head to be registered")
+	}
+	if spec.Extension != htmx.ExtensionHeadSupport {
+		t.Errorf("expected extension %q, got %q", htmx.ExtensionHeadSupport, spec.Extension)
+	}
+}
@@ -0,0 +1,72 @@
+package htmx
+
+import (
+	"net/http"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// HistoryConfig configures HistoryMiddleware.
+type HistoryConfig struct {
+	// FragmentCacheControl, if set, is the Cache-Control header of the
+	// responses to htmx requests that are not history restore requests.
+	//
+	// Use "no-store" so the browser never serves a cached fragment in place
+	// of the full page when navigating back and forward.
+	FragmentCacheControl string
+
+	// RestoreCacheControl, if set, is the Cache-Control header of the
+	// responses to history restore requests.
+	//
+	// Use "no-cache" so the restored snapshot is always revalidated.
+	RestoreCacheControl string
+}
+
+// HistoryMiddleware guarantees full page output for history restore
+// requests, the requests htmx makes after a miss in its history cache.
+//
+// For those requests it removes the HX-Request, HX-Target, HX-Trigger and
+// HX-Trigger-Name headers before calling the next handler, so handlers that
+// decide between a fragment and a full page with ServerGetIsHtmxRequest or
+// ServerWantsFullPage render the full page. The HX-History-Restore-Request
+// header is kept.
+//
+// It also sets the Cache-Control headers configured in HistoryConfig. The
+// handlers can override them.
+//
+// https://htmx.org/docs/#history
+func HistoryMiddleware(config HistoryConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !ServerGetIsHistoryRestoreRequest(r.Header) {
+				if config.FragmentCacheControl != "" && ServerGetIsHtmxRequest(r.Header) {
+					w.Header().Set("Cache-Control", config.FragmentCacheControl)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if config.RestoreCacheControl != "" {
+				w.Header().Set("Cache-Control", config.RestoreCacheControl)
+			}
+
+			r = r.Clone(r.Context())
+			for _, key := range []string{"HX-Request", "HX-Target", "HX-Trigger", "HX-Trigger-Name"} {
+				r.Header.Del(key)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// HistorySensitive renders a div marked with hx-history="false" around the
+// given children.
+//
+// Any page containing it is not saved to the htmx history cache, so
+// sensitive data is not kept in localStorage. htmx requests the page from the
+// server when navigating back to it instead.
+//
+// Output: <div hx-history="false">[children]</div>
+func HistorySensitive(children ...nodx.Node) nodx.Node {
+	return nodx.Div(HxHistoryEnabled(false), nodx.Group(children...))
+}
@@ -0,0 +1,69 @@
+package htmx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+func TestHistoryMiddlewareRestoreRequest(t *testing.T) {
+	config := HistoryConfig{FragmentCacheControl: "no-store", RestoreCacheControl: "no-cache"}
+	handler := HistoryMiddleware(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ServerGetIsHtmxRequest(r.Header) || ServerGetTarget(r.Header) != "" {
+			t.Error("Expected htmx headers to be removed for history restore requests")
+		}
+		if !ServerGetIsHistoryRestoreRequest(r.Header) {
+			t.Error("Expected HX-History-Restore-Request to be kept")
+		}
+	}))
+
+	r := newHtmxRequest(http.MethodGet, "/page")
+	r.Header.Set("HX-History-Restore-Request", "true")
+	r.Header.Set("HX-Target", "main")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+
+	if got := rec.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("Cache-Control: expected %q, got %q", "no-cache", got)
+	}
+	if !ServerGetIsHtmxRequest(r.Header) {
+		t.Error("Expected the original request to be unchanged")
+	}
+}
+
+func TestHistoryMiddlewareFragmentRequest(t *testing.T) {
+	config := HistoryConfig{FragmentCacheControl: "no-store", RestoreCacheControl: "no-cache"}
+	var target string
+	handler := HistoryMiddleware(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target = ServerGetTarget(r.Header)
+	}))
+
+	r := newHtmxRequest(http.MethodGet, "/page")
+	r.Header.Set("HX-Target", "main")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+	if target != "main" {
+		t.Error("Expected htmx headers to be kept")
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-store" {
+		t.Errorf("Cache-Control: expected %q, got %q", "no-store", got)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/page", nil))
+	if got := rec.Header().Get("Cache-Control"); got != "" {
+		t.Errorf("Cache-Control: expected empty for regular requests, got %q", got)
+	}
+}
+
+func TestHistorySensitive(t *testing.T) {
+	node := HistorySensitive(
+		nodx.P(nodx.Text("Account number: 1234")),
+	)
+	expected := `<div hx-history="false"><p>Account number: 1234</p></div>`
+	if got := node.String(); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,92 @@
+package htmx
+
+import (
+	"net/http"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// IndicatorCSS styles the request indicators and the spinner rendered by
+// Spinner.
+//
+// Indicators are hidden until an htmx request of their element, or of one
+// pointing to them with hx-indicator, is in flight. htmx injects similar
+// styles itself unless htmx.config.includeIndicatorStyles is false, which a
+// strict Content Security Policy requires.
+//
+// https://htmx.org/attributes/hx-indicator/
+const IndicatorCSS = `.htmx-indicator{opacity:0}` +
+	`.htmx-request .htmx-indicator,.htmx-request.htmx-indicator{opacity:1;transition:opacity 200ms ease-in}` +
+	`.htmx-request[disabled]{cursor:progress}` +
+	`.htmx-spinner{display:inline-block;width:1em;height:1em;vertical-align:-0.125em;` +
+	`border:0.15em solid currentColor;border-right-color:transparent;border-radius:50%;` +
+	`animation:htmx-spin 0.75s linear infinite}` +
+	`@keyframes htmx-spin{to{transform:rotate(360deg)}}` +
+	`@media (prefers-reduced-motion:reduce){.htmx-spinner{animation-duration:1.5s}}`
+
+// IndicatorStyle renders a <style> element with IndicatorCSS. The nonce, if
+// not empty, is the Content Security Policy nonce of the response.
+//
+// Output: <style nonce="[nonce]">[IndicatorCSS]</style>
+func IndicatorStyle(nonce string) nodx.Node {
+	return nodx.StyleEl(
+		nodx.If(nonce != "", nodx.Nonce(nonce)),
+		nodx.Raw(IndicatorCSS),
+	)
+}
+
+// IndicatorStylesheetHandler returns a handler that serves IndicatorCSS as a
+// stylesheet, for pages whose Content Security Policy forbids inline styles.
+func IndicatorStylesheetHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/css; charset=utf-8")
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+		_, _ = w.Write([]byte(IndicatorCSS))
+	})
+}
+
+// Indicator renders a request indicator, shown only while a request is in
+// flight. Without children it renders a Spinner.
+//
+// Output: <span id="[id]" class="htmx-indicator" role="status">[children]</span>
+//
+// https://htmx.org/attributes/hx-indicator/
+func Indicator(id string, children ...nodx.Node) nodx.Node {
+	if len(children) == 0 {
+		children = []nodx.Node{Spinner()}
+	}
+
+	return nodx.SpanEl(
+		nodx.If(id != "", nodx.Id(id)),
+		nodx.Class("htmx-indicator"),
+		nodx.Role("status"),
+		nodx.Group(children...),
+	)
+}
+
+// Spinner renders a spinning circle, sized and colored like the
+// surrounding text.
+//
+// Output: <span class="htmx-spinner" aria-hidden="true"></span>
+func Spinner() nodx.Node {
+	return nodx.SpanEl(
+		nodx.Class("htmx-spinner"),
+		nodx.Aria("hidden", "true"),
+	)
+}
+
+// IndicatorButton renders a button that shows the indicator matching the
+// given selector and disables itself while its request is in flight, which
+// prevents double submissions. Without a selector the indicators inside the
+// button are shown.
+//
+// Output: <button hx-indicator="[indicator]" hx-disabled-elt="this">[children]</button>
+//
+// https://htmx.org/attributes/hx-disabled-elt/
+func IndicatorButton(indicator string, children ...nodx.Node) nodx.Node {
+	return nodx.Button(
+		nodx.If(indicator != "", HxIndicator(indicator)),
+		HxDisabledELT(SelectorThis),
+		nodx.Group(children...),
+	)
+}
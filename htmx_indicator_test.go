@@ -0,0 +1,50 @@
+package htmx_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func ExampleIndicator() {
+	fmt.Println(htmx.Indicator("saving"))
+	fmt.Println(htmx.Indicator("", nodx.Text("Saving…")))
+	// Output:
+	// <span id="saving" class="htmx-indicator" role="status"><span class="htmx-spinner" aria-hidden="true"></span></span>
+	// <span class="htmx-indicator" role="status">Saving…</span>
+}
+
+func ExampleIndicatorButton() {
+	fmt.Println(htmx.IndicatorButton("#saving", htmx.HxPost("/save"), nodx.Text("Save")))
+	fmt.Println(htmx.IndicatorButton("", htmx.HxPost("/save"), nodx.Text("Save"), htmx.Indicator("")))
+	// Output:
+	// <button hx-indicator="#saving" hx-disabled-elt="this" hx-post="/save">Save</button>
+	// <button hx-disabled-elt="this" hx-post="/save">Save<span class="htmx-indicator" role="status"><span class="htmx-spinner" aria-hidden="true"></span></span></button>
+}
+
+func TestIndicatorStyle(t *testing.T) {
+	got := htmx.IndicatorStyle("abc").String()
+	if got != `<style nonce="abc">`+htmx.IndicatorCSS+`</style>` {
+		t.Errorf("unexpected style %q", got)
+	}
+	if got := htmx.IndicatorStyle("").String(); !strings.HasPrefix(got, "<style>") {
+		t.Errorf("expected no nonce, got %q", got)
+	}
+}
+
+func TestIndicatorStylesheetHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	htmx.IndicatorStylesheetHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/htmx.css", nil))
+
+	if got := rec.Header().Get("Content-Type"); got != "text/css; charset=utf-8" {
+		t.Errorf("Content-Type: unexpected value %q", got)
+	}
+	if rec.Body.String() != htmx.IndicatorCSS {
+		t.Errorf("unexpected body %q", rec.Body.String())
+	}
+}
@@ -0,0 +1,66 @@
+package htmx
+
+import (
+	"net/http"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// LazyTrigger is the event that starts loading a LazyLoad.
+type LazyTrigger string
+
+const (
+	// LazyOnLoad loads the content as soon as the placeholder is loaded.
+	LazyOnLoad LazyTrigger = "load"
+
+	// LazyOnRevealed loads the content when the placeholder scrolls into the
+	// viewport.
+	LazyOnRevealed LazyTrigger = "revealed"
+)
+
+// LazyLoad is a placeholder that loads slow content, such as a dashboard
+// widget, after the page is shown and replaces itself with it.
+//
+// https://htmx.org/examples/lazy-load/
+type LazyLoad struct {
+	// URL is the endpoint of the content, usually rendered with
+	// ServerRenderLazy.
+	URL string
+
+	// Trigger is when the content is loaded. Defaults to LazyOnLoad.
+	Trigger LazyTrigger
+
+	// Indicator, if set, is rendered inside the placeholder. Give it the
+	// htmx-indicator class so it is only shown while loading.
+	Indicator nodx.Node
+}
+
+// Placeholder renders the placeholder with the given children.
+//
+// Output: <div hx-get="[URL]" hx-trigger="[Trigger]" hx-swap="outerHTML">[children][Indicator]</div>
+func (l LazyLoad) Placeholder(children ...nodx.Node) nodx.Node {
+	trigger := l.Trigger
+	if trigger == "" {
+		trigger = LazyOnLoad
+	}
+
+	return nodx.Div(
+		HxGet(l.URL),
+		HxTrigger(string(trigger)),
+		HxSwap("outerHTML"),
+		nodx.Group(children...),
+		nodx.If(l.Indicator != nil, l.Indicator),
+	)
+}
+
+// ServerRenderLazy renders the content of a LazyLoad, setting the
+// "HX-Reswap" header to "outerHTML" so it replaces the placeholder even if
+// the placeholder swap strategy was changed. A nil content removes the
+// placeholder.
+func ServerRenderLazy(w http.ResponseWriter, content nodx.Node) error {
+	if content == nil {
+		content = nodx.Group()
+	}
+	ServerSetReswap(w.Header(), "outerHTML")
+	return ServerRender(w, content)
+}
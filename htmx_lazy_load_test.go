@@ -0,0 +1,51 @@
+package htmx_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func ExampleLazyLoad() {
+	lazy := htmx.LazyLoad{
+		URL:       "/widgets/sales",
+		Trigger:   htmx.LazyOnRevealed,
+		Indicator: nodx.SpanEl(nodx.Class("htmx-indicator"), nodx.Text("Loading…")),
+	}
+	fmt.Println(lazy.Placeholder(nodx.P(nodx.Text("Sales"))))
+	// Output: <div hx-get="/widgets/sales" hx-trigger="revealed" hx-swap="outerHTML"><p>Sales</p><span class="htmx-indicator">Loading…</span></div>
+}
+
+func TestLazyLoadDefaultTrigger(t *testing.T) {
+	expected := `<div hx-get="/w" hx-trigger="load" hx-swap="outerHTML"></div>`
+	if got := (htmx.LazyLoad{URL: "/w"}).Placeholder().String(); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestServerRenderLazy(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if err := htmx.ServerRenderLazy(rec, nodx.Div(nodx.Text("widget"))); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.Header().Get("HX-Reswap"); got != "outerHTML" {
+		t.Errorf("HX-Reswap: expected %q, got %q", "outerHTML", got)
+	}
+	if got := rec.Body.String(); got != "<div>widget</div>" {
+		t.Errorf("unexpected body %q", got)
+	}
+}
+
+func TestServerRenderLazyNil(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if err := htmx.ServerRenderLazy(rec, nil); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Errorf("expected an empty response, got %d %q", rec.Code, rec.Body.String())
+	}
+}
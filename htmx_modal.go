@@ -0,0 +1,98 @@
+package htmx
+
+import (
+	"net/http"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+const (
+	// ModalOpenEvent is the client-side event that opens a Modal.
+	ModalOpenEvent = "modal-open"
+
+	// ModalCloseEvent is the client-side event that closes a Modal.
+	ModalCloseEvent = "modal-close"
+)
+
+// Modal is a native <dialog> whose content is loaded from the server and
+// that the server opens and closes with events.
+//
+// Content is loaded into the dialog by elements carrying the Trigger
+// attributes, and by any response rendered with ServerOpen, e.g. a form that
+// decides to ask for a confirmation. ServerClose closes it, for example after
+// a form inside it was submitted successfully.
+//
+// https://htmx.org/examples/modal-custom/
+type Modal struct {
+	// ID is the id of the dialog. Defaults to "modal".
+	ID string
+}
+
+func (m Modal) id() string {
+	if m.ID == "" {
+		return "modal"
+	}
+	return m.ID
+}
+
+// event returns the detail of an event dispatched on the dialog.
+func (m Modal) event() map[string]string {
+	return map[string]string{"target": "#" + m.id()}
+}
+
+// Container renders the dialog with the given initial children. It must be
+// rendered once in the page, usually at the end of the body.
+//
+// Output: <dialog id="[ID]" hx-on:modal-open="this.showModal()" hx-on:modal-close="this.close()">[children]</dialog>
+func (m Modal) Container(children ...nodx.Node) nodx.Node {
+	return nodx.Dialog(
+		nodx.Id(m.id()),
+		HxOn(ModalOpenEvent, "this.showModal()"),
+		HxOn(ModalCloseEvent, "this.close()"),
+		nodx.Group(children...),
+	)
+}
+
+// Trigger renders the attributes that load the content at the given URL into
+// the dialog. The endpoint should respond with ServerOpen so the dialog is
+// shown once the content is swapped.
+//
+// Output: hx-get="[url]" hx-target="#[ID]" hx-swap="innerHTML"
+func (m Modal) Trigger(url string) nodx.Node {
+	return nodx.Group(
+		HxGet(url),
+		HxTarget("#"+m.id()),
+		HxSwap("innerHTML"),
+	)
+}
+
+// ServerOpen renders the content into the dialog and opens it.
+//
+// It sets the "HX-Retarget" and "HX-Reswap" headers so the content replaces
+// the dialog content regardless of the element that made the request, and
+// triggers ModalOpenEvent on the dialog after the content is settled.
+func (m Modal) ServerOpen(w http.ResponseWriter, r *http.Request, content nodx.Node) error {
+	if err := ServerAddTriggerAfterSettle(w, r, ModalOpenEvent, m.event()); err != nil {
+		return err
+	}
+	ServerSetRetarget(w.Header(), "#"+m.id())
+	ServerSetReswap(w.Header(), "innerHTML")
+
+	return ServerRender(w, content)
+}
+
+// ServerClose closes the dialog.
+//
+// It sets the "HX-Reswap" header to "none" so the element that made the
+// request is left untouched, and triggers ModalCloseEvent on the dialog. The
+// oob nodes, if any, are rendered as the response body to refresh the page
+// behind the dialog, e.g. the list an item was just added to, and must carry
+// an hx-swap-oob attribute (see HxSwapOOB).
+func (m Modal) ServerClose(w http.ResponseWriter, r *http.Request, oob ...nodx.Node) error {
+	if err := ServerAddTrigger(w, r, ModalCloseEvent, m.event()); err != nil {
+		return err
+	}
+	ServerSetReswap(w.Header(), "none")
+
+	return ServerRender(w, nodx.Group(oob...))
+}
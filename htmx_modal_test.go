@@ -0,0 +1,69 @@
+package htmx_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func ExampleModal() {
+	modal := htmx.Modal{ID: "dialog"}
+	fmt.Println(modal.Container())
+	fmt.Println(nodx.Button(modal.Trigger("/contacts/new"), nodx.Text("New contact")))
+	// Output:
+	// <dialog id="dialog" hx-on:modal-open="this.showModal()" hx-on:modal-close="this.close()"></dialog>
+	// <button hx-get="/contacts/new" hx-target="#dialog" hx-swap="innerHTML">New contact</button>
+}
+
+func TestModalServerOpen(t *testing.T) {
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/contacts", nil)
+	r.Header.Set("HX-Request", "true")
+	if err := (htmx.Modal{}).ServerOpen(rec, r, nodx.P(nodx.Text("Confirm"))); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedHeaders := map[string]string{
+		"HX-Retarget":             "#modal",
+		"HX-Reswap":               "innerHTML",
+		"HX-Trigger-After-Settle": `{"modal-open":{"target":"#modal"}}`,
+	}
+	for key, expected := range expectedHeaders {
+		if got := rec.Header().Get(key); got != expected {
+			t.Errorf("%s: expected %q, got %q", key, expected, got)
+		}
+	}
+	if got := rec.Body.String(); got != "<p>Confirm</p>" {
+		t.Errorf("unexpected body %q", got)
+	}
+}
+
+func TestModalServerClose(t *testing.T) {
+	handler := htmx.ResponseMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		htmx.ServerSetTrigger(w.Header(), "saved")
+		oob := nodx.Ul(nodx.Id("contacts"), htmx.HxSwapOOB("true"))
+		if err := (htmx.Modal{}).ServerClose(w, r, oob); err != nil {
+			t.Fatal(err)
+		}
+	}))
+
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/contacts", nil)
+	r.Header.Set("HX-Request", "true")
+	handler.ServeHTTP(rec, r)
+
+	if got := rec.Header().Get("HX-Reswap"); got != "none" {
+		t.Errorf("HX-Reswap: expected %q, got %q", "none", got)
+	}
+	expected := `{"saved":null,"modal-close":{"target":"#modal"}}`
+	if got := rec.Header().Get("HX-Trigger"); got != expected {
+		t.Errorf("HX-Trigger: expected %q, got %q", expected, got)
+	}
+	if got := rec.Body.String(); got != `<ul id="contacts" hx-swap-oob="true"></ul>` {
+		t.Errorf("unexpected body %q", got)
+	}
+}
@@ -0,0 +1,179 @@
+package htmx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// ErrInvalidPage is returned when a request has an invalid page cursor or
+// limit.
+var ErrInvalidPage = errors.New("invalid page")
+
+// PageConfig configures how pages are read from the request query.
+type PageConfig struct {
+	// CursorParam is the query parameter of the cursor. Defaults to "cursor".
+	CursorParam string
+
+	// LimitParam is the query parameter of the page size. Defaults to "limit".
+	LimitParam string
+
+	// DefaultLimit is the page size when the request has none. Defaults to 20.
+	DefaultLimit int
+
+	// MaxLimit is the maximum page size, bigger limits are capped. Defaults
+	// to 100.
+	MaxLimit int
+}
+
+func (c PageConfig) withDefaults() PageConfig {
+	if c.CursorParam == "" {
+		c.CursorParam = "cursor"
+	}
+	if c.LimitParam == "" {
+		c.LimitParam = "limit"
+	}
+	if c.DefaultLimit <= 0 {
+		c.DefaultLimit = 20
+	}
+	if c.MaxLimit <= 0 {
+		c.MaxLimit = 100
+	}
+	return c
+}
+
+// Page is a page requested by the client.
+//
+// The cursor is opaque: it can be anything the data layer understands, such
+// as the id of the last item, or an offset created with OffsetCursor.
+type Page struct {
+	// Cursor is where the page starts, empty for the first page.
+	Cursor string
+
+	// Limit is the maximum number of items of the page.
+	Limit int
+}
+
+// OffsetCursor returns the cursor of a page starting at the given offset.
+func OffsetCursor(offset int) string {
+	return strconv.Itoa(offset)
+}
+
+// Offset returns the offset of a page whose cursor was created with
+// OffsetCursor. The first page has offset 0.
+func (p Page) Offset() (int, error) {
+	if p.Cursor == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(p.Cursor)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("%w: cursor %q is not an offset", ErrInvalidPage, p.Cursor)
+	}
+	return offset, nil
+}
+
+// NextOffsetCursor returns the offset cursor of the page following this one,
+// given the number of items it returned. It returns an empty cursor when the
+// page was not full, meaning there is no more data.
+func (p Page) NextOffsetCursor(count int) string {
+	if count < p.Limit {
+		return ""
+	}
+	offset, err := p.Offset()
+	if err != nil {
+		return ""
+	}
+	return OffsetCursor(offset + count)
+}
+
+// ServerGetPage returns the page requested in the query, returning
+// ErrInvalidPage if the limit is not a positive number.
+func ServerGetPage(r *http.Request, config PageConfig) (Page, error) {
+	config = config.withDefaults()
+	query := r.URL.Query()
+
+	page := Page{
+		Cursor: query.Get(config.CursorParam),
+		Limit:  config.DefaultLimit,
+	}
+
+	if value := query.Get(config.LimitParam); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return page, fmt.Errorf("%w: limit %q is not a positive number", ErrInvalidPage, value)
+		}
+		page.Limit = min(limit, config.MaxLimit)
+	}
+
+	return page, nil
+}
+
+// ServerNextPageURL returns the URL of the request with the cursor query
+// parameter set to the given cursor, or an empty string if the cursor is
+// empty, meaning there is no more data.
+func ServerNextPageURL(r *http.Request, config PageConfig, cursor string) string {
+	if cursor == "" {
+		return ""
+	}
+	config = config.withDefaults()
+
+	u := *r.URL
+	query := u.Query()
+	query.Set(config.CursorParam, cursor)
+	u.RawQuery = query.Encode()
+
+	return u.RequestURI()
+}
+
+// InfiniteScroll renders the items with the row function, passing the
+// infinite scroll attributes to the last row so that, when revealed, it
+// loads the next page and appends it after itself. The other rows get an
+// empty node.
+//
+// The attributes are hx-get="[nextURL]", hx-trigger="revealed" and
+// hx-swap="afterend". When nextURL is empty, meaning there is no more data,
+// no row gets them.
+//
+// https://htmx.org/examples/infinite-scroll/
+func InfiniteScroll[T any](items []T, nextURL string, row func(item T, sentinel nodx.Node) nodx.Node) nodx.Node {
+	nodes := make([]nodx.Node, len(items))
+	for i, item := range items {
+		sentinel := nodx.Group()
+		if nextURL != "" && i == len(items)-1 {
+			sentinel = nodx.Group(
+				HxGet(nextURL),
+				HxTrigger("revealed"),
+				HxSwap("afterend"),
+			)
+		}
+		nodes[i] = row(item, sentinel)
+	}
+	return nodx.Group(nodes...)
+}
+
+// LoadMoreButton renders a button that loads the next page and replaces the
+// target with it. The target defaults to the button itself; use
+// SelectorClosest("tr") when the button is inside a table row.
+//
+// The next page should end with a new button. When nextURL is empty, meaning
+// there is no more data, it renders nothing.
+//
+// https://htmx.org/examples/click-to-load/
+func LoadMoreButton(nextURL string, target string, children ...nodx.Node) nodx.Node {
+	if nextURL == "" {
+		return nodx.Group()
+	}
+	if target == "" {
+		target = SelectorThis
+	}
+	return nodx.Button(
+		nodx.Type("button"),
+		HxGet(nextURL),
+		HxTarget(target),
+		HxSwap("outerHTML"),
+		nodx.Group(children...),
+	)
+}
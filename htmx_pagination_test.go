@@ -0,0 +1,89 @@
+package htmx_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func ExampleInfiniteScroll() {
+	rows := htmx.InfiniteScroll([]string{"a", "b"}, "/items?cursor=2", func(item string, sentinel nodx.Node) nodx.Node {
+		return nodx.Tr(sentinel, nodx.Td(nodx.Text(item)))
+	})
+	fmt.Println(rows)
+	// Output: <tr><td>a</td></tr><tr hx-get="/items?cursor=2" hx-trigger="revealed" hx-swap="afterend"><td>b</td></tr>
+}
+
+func ExampleLoadMoreButton() {
+	fmt.Println(htmx.LoadMoreButton("/items?cursor=2", "", nodx.Text("Load more")))
+	fmt.Println(htmx.LoadMoreButton("/items?cursor=2", htmx.SelectorClosest("tr"), nodx.Text("Load more")))
+	// Output:
+	// <button type="button" hx-get="/items?cursor=2" hx-target="this" hx-swap="outerHTML">Load more</button>
+	// <button type="button" hx-get="/items?cursor=2" hx-target="closest tr" hx-swap="outerHTML">Load more</button>
+}
+
+func TestLoadMoreButtonLastPage(t *testing.T) {
+	if got := htmx.LoadMoreButton("", "", nodx.Text("Load more")).String(); got != "" {
+		t.Errorf("expected no button, got %q", got)
+	}
+}
+
+func TestInfiniteScrollLastPage(t *testing.T) {
+	rows := htmx.InfiniteScroll([]int{1}, "", func(item int, sentinel nodx.Node) nodx.Node {
+		return nodx.Li(sentinel, nodx.Textf("%d", item))
+	})
+	if got := rows.String(); got != "<li>1</li>" {
+		t.Errorf("expected no sentinel, got %q", got)
+	}
+}
+
+func TestServerGetPage(t *testing.T) {
+	config := htmx.PageConfig{MaxLimit: 50}
+
+	page, err := htmx.ServerGetPage(httptest.NewRequest(http.MethodGet, "/", nil), config)
+	if err != nil || page.Cursor != "" || page.Limit != 20 {
+		t.Errorf("unexpected default page %+v %v", page, err)
+	}
+
+	page, err = htmx.ServerGetPage(httptest.NewRequest(http.MethodGet, "/?cursor=40&limit=500", nil), config)
+	if err != nil || page.Cursor != "40" || page.Limit != 50 {
+		t.Errorf("unexpected page %+v %v", page, err)
+	}
+
+	_, err = htmx.ServerGetPage(httptest.NewRequest(http.MethodGet, "/?limit=-1", nil), config)
+	if !errors.Is(err, htmx.ErrInvalidPage) {
+		t.Errorf("expected ErrInvalidPage, got %v", err)
+	}
+}
+
+func TestPageOffset(t *testing.T) {
+	page := htmx.Page{Cursor: htmx.OffsetCursor(40), Limit: 20}
+	if offset, err := page.Offset(); err != nil || offset != 40 {
+		t.Errorf("expected offset 40, got %d %v", offset, err)
+	}
+	if next := page.NextOffsetCursor(20); next != "60" {
+		t.Errorf("expected next cursor 60, got %q", next)
+	}
+	if next := page.NextOffsetCursor(19); next != "" {
+		t.Errorf("expected no next cursor for a partial page, got %q", next)
+	}
+
+	if _, err := (htmx.Page{Cursor: "abc"}).Offset(); !errors.Is(err, htmx.ErrInvalidPage) {
+		t.Errorf("expected ErrInvalidPage, got %v", err)
+	}
+}
+
+func TestServerNextPageURL(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/items?cursor=20&sort=name", nil)
+	if got := htmx.ServerNextPageURL(r, htmx.PageConfig{}, "40"); got != "/items?cursor=40&sort=name" {
+		t.Errorf("unexpected next URL %q", got)
+	}
+	if got := htmx.ServerNextPageURL(r, htmx.PageConfig{}, ""); got != "" {
+		t.Errorf("expected empty next URL, got %q", got)
+	}
+}
@@ -0,0 +1,105 @@
+package htmx
+
+import (
+	"net/http"
+	"time"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// StatusStopPolling is the response status code that makes htmx stop
+// polling. The response content is still swapped.
+//
+// https://htmx.org/docs/#polling
+const StatusStopPolling = 286
+
+// Poll is an element that periodically reloads itself, e.g. a progress bar
+// or a job status view.
+//
+// https://htmx.org/examples/progress-bar/
+type Poll struct {
+	// URL is the status endpoint, usually rendered with ServerPoll.
+	URL string
+
+	// Interval is the time between requests. Defaults to one second.
+	Interval time.Duration
+}
+
+// Node renders the polling element with the given children.
+//
+// It replaces itself with every response, so the status endpoint must
+// render the same Poll node while the job is running.
+//
+// Output: <div hx-get="[URL]" hx-trigger="every [Interval]" hx-swap="outerHTML">[children]</div>
+func (p Poll) Node(children ...nodx.Node) nodx.Node {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	return nodx.Div(
+		HxGet(p.URL),
+		HxTrigger("every "+formatDuration(interval)),
+		HxSwap("outerHTML"),
+		nodx.Group(children...),
+	)
+}
+
+// JobState is the state of the job watched by a Poll.
+type JobState int
+
+const (
+	// JobRunning keeps polling.
+	JobRunning JobState = iota
+
+	// JobDone stops polling.
+	JobDone
+
+	// JobFailed stops polling.
+	JobFailed
+)
+
+// PollResult is the response to a Poll request.
+type PollResult struct {
+	// State is the job state.
+	State JobState
+
+	// Content is the fragment that replaces the Poll node. While the job is
+	// running it should be the Poll node itself, afterwards the final view.
+	Content nodx.Node
+
+	// Event, if set, is the client-side event triggered when the job is done
+	// or failed.
+	Event string
+
+	// EventDetail is the detail of Event, encoded as JSON.
+	EventDetail any
+}
+
+// ServerPoll renders the response to a Poll request.
+//
+// While the job is running it renders the content. Once it is done or failed
+// it triggers the completion event, if any, and renders the content with the
+// StatusStopPolling status code so htmx stops polling.
+func ServerPoll(w http.ResponseWriter, r *http.Request, result PollResult) error {
+	content := result.Content
+	if content == nil {
+		content = nodx.Group()
+	}
+
+	if result.State == JobRunning {
+		return ServerRender(w, content)
+	}
+
+	if result.Event != "" {
+		if err := ServerAddTrigger(w, r, result.Event, result.EventDetail); err != nil {
+			return err
+		}
+	}
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	}
+	w.WriteHeader(StatusStopPolling)
+	return ServerRender(w, content)
+}
@@ -0,0 +1,83 @@
+package htmx_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func ExamplePoll() {
+	poll := htmx.Poll{URL: "/jobs/1", Interval: 2 * time.Second}
+	fmt.Println(poll.Node(nodx.Progress(nodx.Value("40"), nodx.Max("100"))))
+	fmt.Println(htmx.Poll{URL: "/jobs/1", Interval: 1500 * time.Millisecond}.Node())
+	// Output:
+	// <div hx-get="/jobs/1" hx-trigger="every 2s" hx-swap="outerHTML"><progress value="40" max="100"></progress></div>
+	// <div hx-get="/jobs/1" hx-trigger="every 1500ms" hx-swap="outerHTML"></div>
+}
+
+func TestServerPollRunning(t *testing.T) {
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/jobs/1", nil)
+	err := htmx.ServerPoll(rec, r, htmx.PollResult{
+		State:   htmx.JobRunning,
+		Content: htmx.Poll{URL: "/jobs/1"}.Node(),
+		Event:   "jobDone",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("HX-Trigger"); got != "" {
+		t.Errorf("HX-Trigger: expected empty while running, got %q", got)
+	}
+}
+
+func TestServerPollDone(t *testing.T) {
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/jobs/1", nil)
+	err := htmx.ServerPoll(rec, r, htmx.PollResult{
+		State:       htmx.JobDone,
+		Content:     nodx.P(nodx.Text("Done")),
+		Event:       "jobDone",
+		EventDetail: map[string]int{"id": 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != htmx.StatusStopPolling {
+		t.Errorf("expected %d, got %d", htmx.StatusStopPolling, rec.Code)
+	}
+	if got := rec.Header().Get("HX-Trigger"); got != `{"jobDone":{"id":1}}` {
+		t.Errorf("HX-Trigger: unexpected value %q", got)
+	}
+	if got := rec.Body.String(); got != "<p>Done</p>" {
+		t.Errorf("unexpected body %q", got)
+	}
+}
+
+func TestPollInterval(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		expected string
+	}{
+		{0, `hx-trigger="every 1s"`},
+		{time.Minute, `hx-trigger="every 60s"`},
+		{250 * time.Millisecond, `hx-trigger="every 250ms"`},
+		{500 * time.Microsecond, `hx-trigger="every 1ms"`},
+		{1500 * time.Microsecond, `hx-trigger="every 2ms"`},
+	}
+	for _, tt := range tests {
+		got := (htmx.Poll{URL: "/jobs/1", Interval: tt.interval}).Node().String()
+		if !strings.Contains(got, tt.expected) {
+			t.Errorf("%s: expected %s, got %q", tt.interval, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package htmx
+
+import (
+	"fmt"
+	"net/http"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// ServerWantsFullPage reports whether the response to the request must be a
+// full page instead of a fragment, see Request.WantsFullPage.
+func ServerWantsFullPage(headers http.Header) bool {
+	return ServerParseRequest(headers).WantsFullPage()
+}
+
+// ServerRender renders the node to the response as HTML.
+//
+// The Content-Type header is set to "text/html; charset=utf-8" unless the
+// handler already set one.
+func ServerRender(w http.ResponseWriter, node nodx.Node) error {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	}
+	if err := node.Render(w); err != nil {
+		return fmt.Errorf("failed to render response: %w", err)
+	}
+	return nil
+}
+
+// ServerRenderPartial renders the full page when ServerWantsFullPage reports
+// true and only the fragment otherwise.
+//
+// Only the function for the chosen output is called.
+func ServerRenderPartial(w http.ResponseWriter, r *http.Request, page func() nodx.Node, fragment func() nodx.Node) error {
+	if ServerWantsFullPage(r.Header) {
+		return ServerRender(w, page())
+	}
+	return ServerRender(w, fragment())
+}
@@ -0,0 +1,64 @@
+package htmx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+func TestServerWantsFullPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		expected bool
+	}{
+		{"regular request", map[string]string{}, true},
+		{"htmx request", map[string]string{"HX-Request": "true"}, false},
+		{"boosted request", map[string]string{"HX-Request": "true", "HX-Boosted": "true"}, true},
+		{"history restore", map[string]string{"HX-Request": "true", "HX-History-Restore-Request": "true"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := http.Header{}
+			for k, v := range tt.headers {
+				headers.Set(k, v)
+			}
+			if got := ServerWantsFullPage(headers); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestServerRender(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if err := ServerRender(rec, nodx.P(nodx.Text("hi"))); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "text/html; charset=utf-8" {
+		t.Errorf("Content-Type: expected html, got %q", got)
+	}
+	if got := rec.Body.String(); got != "<p>hi</p>" {
+		t.Errorf("body: expected %q, got %q", "<p>hi</p>", got)
+	}
+}
+
+func TestServerRenderPartial(t *testing.T) {
+	page := func() nodx.Node { return nodx.Text("page") }
+	fragment := func() nodx.Node { return nodx.Text("fragment") }
+
+	rec := httptest.NewRecorder()
+	_ = ServerRenderPartial(rec, httptest.NewRequest(http.MethodGet, "/", nil), page, fragment)
+	if got := rec.Body.String(); got != "page" {
+		t.Errorf("expected page for regular requests, got %q", got)
+	}
+
+	rec = httptest.NewRecorder()
+	_ = ServerRenderPartial(rec, newHtmxRequest(http.MethodGet, "/"), page, fragment)
+	if got := rec.Body.String(); got != "fragment" {
+		t.Errorf("expected fragment for htmx requests, got %q", got)
+	}
+}
@@ -0,0 +1,55 @@
+package htmx
+
+import "net/http"
+
+// Request holds the htmx request headers of a request.
+type Request struct {
+	// IsHtmx is true for requests made by htmx ("HX-Request").
+	IsHtmx bool
+
+	// IsBoosted is true for requests made by a boosted element ("HX-Boosted").
+	IsBoosted bool
+
+	// IsHistoryRestore is true for history restore requests after a miss in
+	// the history cache ("HX-History-Restore-Request").
+	IsHistoryRestore bool
+
+	// CurrentURL is the current URL of the browser ("HX-Current-URL").
+	CurrentURL string
+
+	// Prompt is the user response to an hx-prompt ("HX-Prompt").
+	Prompt string
+
+	// Target is the id of the target element ("HX-Target").
+	Target string
+
+	// Trigger is the id of the triggered element ("HX-Trigger").
+	Trigger string
+
+	// TriggerName is the name of the triggered element ("HX-Trigger-Name").
+	TriggerName string
+}
+
+// ServerParseRequest returns the htmx request headers of a request.
+func ServerParseRequest(headers http.Header) Request {
+	return Request{
+		IsHtmx:           ServerGetIsHtmxRequest(headers),
+		IsBoosted:        ServerGetIsBoosted(headers),
+		IsHistoryRestore: ServerGetIsHistoryRestoreRequest(headers),
+		CurrentURL:       ServerGetCurrentURL(headers),
+		Prompt:           ServerGetPrompt(headers),
+		Target:           ServerGetTarget(headers),
+		Trigger:          ServerGetTrigger(headers),
+		TriggerName:      ServerGetTriggerName(headers),
+	}
+}
+
+// WantsFullPage reports whether the response to the request must be a full
+// page instead of a fragment.
+//
+// That is the case for regular browser requests, boosted requests (htmx
+// swaps the body of the returned document) and history restore requests
+// (htmx expects the whole page after a miss in its history cache).
+func (r Request) WantsFullPage() bool {
+	return !r.IsHtmx || r.IsBoosted || r.IsHistoryRestore
+}
@@ -0,0 +1,93 @@
+package htmx
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// RequestConfig is the typed configuration of an hx-request attribute.
+//
+// https://htmx.org/attributes/hx-request/
+type RequestConfig struct {
+	// Timeout is the request timeout, rounded down to milliseconds. Zero means
+	// no timeout.
+	Timeout time.Duration
+
+	// Credentials indicates whether the request will send credentials.
+	Credentials bool
+
+	// NoHeaders strips all htmx headers from the request.
+	NoHeaders bool
+}
+
+// String returns the configuration in the JSON format expected by hx-request.
+//
+// Fields with zero values are omitted.
+func (c RequestConfig) String() string {
+	fields := []string{}
+	if ms := c.Timeout.Milliseconds(); ms > 0 {
+		fields = append(fields, `"timeout":`+strconv.FormatInt(ms, 10))
+	}
+	if c.Credentials {
+		fields = append(fields, `"credentials":true`)
+	}
+	if c.NoHeaders {
+		fields = append(fields, `"noHeaders":true`)
+	}
+	return "{" + strings.Join(fields, ",") + "}"
+}
+
+// RequestConfigJS is the dynamic configuration of an hx-request attribute,
+// where every field is a JavaScript expression evaluated by htmx at request
+// time.
+//
+// https://htmx.org/attributes/hx-request/
+type RequestConfigJS struct {
+	// Timeout is an expression that evaluates to the timeout in milliseconds.
+	Timeout string
+
+	// Credentials is an expression that evaluates to a boolean.
+	Credentials string
+
+	// NoHeaders is an expression that evaluates to a boolean.
+	NoHeaders string
+}
+
+// String returns the configuration in the "js:" format expected by
+// hx-request.
+//
+// Empty fields are omitted.
+func (c RequestConfigJS) String() string {
+	fields := []string{}
+	if c.Timeout != "" {
+		fields = append(fields, "timeout: "+c.Timeout)
+	}
+	if c.Credentials != "" {
+		fields = append(fields, "credentials: "+c.Credentials)
+	}
+	if c.NoHeaders != "" {
+		fields = append(fields, "noHeaders: "+c.NoHeaders)
+	}
+	return "js: " + strings.Join(fields, ", ")
+}
+
+// HxRequestConfig renders an hx-request="[config]" attribute.
+//
+// It is the typed version of HxRequest.
+//
+// https://htmx.org/attributes/hx-request/
+func HxRequestConfig(config RequestConfig) nodx.Node {
+	return HxRequest(config.String())
+}
+
+// HxRequestConfigJS renders an hx-request="js: [config]" attribute.
+//
+// It is the typed version of HxRequest for dynamic values.
+//
+// https://htmx.org/attributes/hx-request/
+func HxRequestConfigJS(config RequestConfigJS) nodx.Node {
+	return HxRequest(config.String())
+}
@@ -0,0 +1,47 @@
+package htmx_test
+
+import (
+	"fmt"
+	"time"
+
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func ExampleRequestConfig() {
+	fmt.Println(htmx.RequestConfig{})
+	fmt.Println(htmx.RequestConfig{Timeout: 1500 * time.Millisecond})
+	fmt.Println(htmx.RequestConfig{
+		Timeout:     time.Second,
+		Credentials: true,
+		NoHeaders:   true,
+	})
+	// Output:
+	// {}
+	// {"timeout":1500}
+	// {"timeout":1000,"credentials":true,"noHeaders":true}
+}
+
+func ExampleRequestConfigJS() {
+	fmt.Println(htmx.RequestConfigJS{
+		Timeout:   "getTimeoutSetting()",
+		NoHeaders: "true",
+	})
+	// Output: js: timeout: getTimeoutSetting(), noHeaders: true
+}
+
+func ExampleHxRequestConfig() {
+	node := nodx.Div(
+		htmx.HxRequestConfig(htmx.RequestConfig{Timeout: 100 * time.Millisecond}),
+	)
+	fmt.Println(node)
+	// Output: <div hx-request="{&quot;timeout&quot;:100}"></div>
+}
+
+func ExampleHxRequestConfigJS() {
+	node := nodx.Div(
+		htmx.HxRequestConfigJS(htmx.RequestConfigJS{Timeout: "getTimeoutSetting()"}),
+	)
+	fmt.Println(node)
+	// Output: <div hx-request="js: timeout: getTimeoutSetting()"></div>
+}
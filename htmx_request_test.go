@@ -0,0 +1,47 @@
+package htmx_test
+
+import (
+	"net/http"
+	"testing"
+
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func TestServerParseRequest(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("HX-Request", "true")
+	headers.Set("HX-Current-URL", "https://example.com/a")
+	headers.Set("HX-Prompt", "yes")
+	headers.Set("HX-Target", "list")
+	headers.Set("HX-Trigger", "btn")
+	headers.Set("HX-Trigger-Name", "save")
+
+	expected := htmx.Request{
+		IsHtmx:      true,
+		CurrentURL:  "https://example.com/a",
+		Prompt:      "yes",
+		Target:      "list",
+		Trigger:     "btn",
+		TriggerName: "save",
+	}
+	if got := htmx.ServerParseRequest(headers); got != expected {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestRequestWantsFullPage(t *testing.T) {
+	tests := []struct {
+		req      htmx.Request
+		expected bool
+	}{
+		{htmx.Request{}, true},
+		{htmx.Request{IsHtmx: true}, false},
+		{htmx.Request{IsHtmx: true, IsBoosted: true}, true},
+		{htmx.Request{IsHtmx: true, IsHistoryRestore: true}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.req.WantsFullPage(); got != tt.expected {
+			t.Errorf("%+v: expected %v, got %v", tt.req, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,450 @@
+package htmx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// contextKey is the type of the request context keys used by this package.
+type contextKey int
+
+const (
+	responseContextKey contextKey = iota
+	flashContextKey
+)
+
+// Response accumulates the htmx response headers and out of band fragments
+// set by the different layers (middleware, handlers, components) that take
+// part in a request, and merges them right before the headers are written.
+//
+// Unlike the ServerSet* helpers, which overwrite any previous value, a
+// Response lets every layer append its own events without knowing about the
+// others.
+//
+// A Response is installed by ResponseMiddleware and retrieved with
+// ServerResponse. It is safe for concurrent use.
+type Response struct {
+	mu                  sync.Mutex
+	triggers            []triggerEvent
+	triggersAfterSettle []triggerEvent
+	triggersAfterSwap   []triggerEvent
+	oob                 []nodx.Node
+	pushURL             string
+	replaceURL          string
+	beforeHeaders       []func(w http.ResponseWriter, status int)
+}
+
+// triggerEvent is an event sent in one of the HX-Trigger* response headers.
+type triggerEvent struct {
+	name string
+	// detail is the JSON encoded event detail, nil if the event has none.
+	detail json.RawMessage
+}
+
+// newTriggerEvent creates a trigger event, encoding its detail as JSON.
+func newTriggerEvent(name string, detail any) (triggerEvent, error) {
+	event := triggerEvent{name: name}
+	if detail == nil {
+		return event, nil
+	}
+
+	b, err := json.Marshal(detail)
+	if err != nil {
+		return event, fmt.Errorf("failed to encode %q event detail: %w", name, err)
+	}
+	event.detail = b
+
+	return event, nil
+}
+
+// AddTrigger queues a client-side event for the "HX-Trigger" response header.
+//
+// The detail is encoded as JSON and can be nil if the event has none.
+//
+// https://htmx.org/headers/hx-trigger/
+func (res *Response) AddTrigger(name string, detail any) error {
+	return res.addTrigger(&res.triggers, name, detail)
+}
+
+// AddTriggerAfterSettle queues a client-side event for the
+// "HX-Trigger-After-Settle" response header.
+//
+// The detail is encoded as JSON and can be nil if the event has none.
+//
+// https://htmx.org/headers/hx-trigger/
+func (res *Response) AddTriggerAfterSettle(name string, detail any) error {
+	return res.addTrigger(&res.triggersAfterSettle, name, detail)
+}
+
+// AddTriggerAfterSwap queues a client-side event for the
+// "HX-Trigger-After-Swap" response header.
+//
+// The detail is encoded as JSON and can be nil if the event has none.
+//
+// https://htmx.org/headers/hx-trigger/
+func (res *Response) AddTriggerAfterSwap(name string, detail any) error {
+	return res.addTrigger(&res.triggersAfterSwap, name, detail)
+}
+
+func (res *Response) addTrigger(events *[]triggerEvent, name string, detail any) error {
+	event, err := newTriggerEvent(name, detail)
+	if err != nil {
+		return err
+	}
+
+	res.mu.Lock()
+	defer res.mu.Unlock()
+	*events = append(*events, event)
+
+	return nil
+}
+
+// AddOOB queues out of band fragments to be appended to the response body.
+//
+// The nodes should carry an hx-swap-oob attribute (see HxSwapOOB). They are
+// only rendered for htmx requests, and never for responses without a body.
+//
+// https://htmx.org/attributes/hx-swap-oob/
+func (res *Response) AddOOB(nodes ...nodx.Node) {
+	res.mu.Lock()
+	defer res.mu.Unlock()
+	res.oob = append(res.oob, nodes...)
+}
+
+// SetPushURL sets the URL for the "HX-Push-Url" response header, replacing
+// any URL set before.
+//
+// https://htmx.org/headers/hx-push-url/
+func (res *Response) SetPushURL(url string) {
+	res.mu.Lock()
+	defer res.mu.Unlock()
+	res.pushURL = url
+}
+
+// SetReplaceURL sets the URL for the "HX-Replace-Url" response header,
+// replacing any URL set before.
+//
+// https://htmx.org/headers/hx-replace-url/
+func (res *Response) SetReplaceURL(url string) {
+	res.mu.Lock()
+	defer res.mu.Unlock()
+	res.replaceURL = url
+}
+
+// onBeforeHeaders registers a function that runs right before the
+// accumulated values are merged into the headers, so it can still add to
+// them. The writer must only be used to modify the headers.
+func (res *Response) onBeforeHeaders(fn func(w http.ResponseWriter, status int)) {
+	res.mu.Lock()
+	defer res.mu.Unlock()
+	res.beforeHeaders = append(res.beforeHeaders, fn)
+}
+
+// runBeforeHeaders runs the functions registered with onBeforeHeaders.
+func (res *Response) runBeforeHeaders(w http.ResponseWriter, status int) {
+	res.mu.Lock()
+	hooks := res.beforeHeaders
+	res.beforeHeaders = nil
+	res.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook(w, status)
+	}
+}
+
+// applyHeaders merges the accumulated values into the response headers.
+//
+// Events are merged with any value already present in the HX-Trigger*
+// headers, e.g. one set with ServerSetTrigger.
+func (res *Response) applyHeaders(headers http.Header) {
+	res.mu.Lock()
+	defer res.mu.Unlock()
+
+	mergeTriggerHeader(headers, "HX-Trigger", res.triggers)
+	mergeTriggerHeader(headers, "HX-Trigger-After-Settle", res.triggersAfterSettle)
+	mergeTriggerHeader(headers, "HX-Trigger-After-Swap", res.triggersAfterSwap)
+
+	if res.pushURL != "" {
+		ServerSetPushURL(headers, res.pushURL)
+	}
+	if res.replaceURL != "" {
+		ServerSetReplaceURL(headers, res.replaceURL)
+	}
+}
+
+// takeOOB returns the queued out of band fragments and clears the queue.
+func (res *Response) takeOOB() []nodx.Node {
+	res.mu.Lock()
+	defer res.mu.Unlock()
+	nodes := res.oob
+	res.oob = nil
+	return nodes
+}
+
+// mergeTriggerHeader merges the events into the given HX-Trigger* header.
+//
+// If none of the events has a detail the header is a comma-separated list of
+// event names, otherwise it is a JSON object. When an event is repeated the
+// last detail wins.
+func mergeTriggerHeader(headers http.Header, key string, events []triggerEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	merged := parseTriggerHeader(headers.Get(key))
+	for _, event := range events {
+		replaced := false
+		for i := range merged {
+			if merged[i].name == event.name {
+				merged[i] = event
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, event)
+		}
+	}
+
+	headers.Set(key, formatTriggerHeader(merged))
+}
+
+// parseTriggerHeader parses an HX-Trigger* header value in either the JSON
+// object or the comma-separated format.
+func parseTriggerHeader(value string) []triggerEvent {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	if !strings.HasPrefix(value, "{") {
+		events := []triggerEvent{}
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				events = append(events, triggerEvent{name: name})
+			}
+		}
+		return events
+	}
+
+	// The object is decoded token by token to keep the order of the events.
+	dec := json.NewDecoder(strings.NewReader(value))
+	if _, err := dec.Token(); err != nil {
+		return nil
+	}
+	events := []triggerEvent{}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return events
+		}
+		name, _ := token.(string)
+		var detail json.RawMessage
+		if err := dec.Decode(&detail); err != nil {
+			return events
+		}
+		if string(detail) == "null" {
+			detail = nil
+		}
+		events = append(events, triggerEvent{name: name, detail: detail})
+	}
+
+	return events
+}
+
+// formatTriggerHeader formats the events as an HX-Trigger* header value.
+func formatTriggerHeader(events []triggerEvent) string {
+	hasDetail := false
+	for _, event := range events {
+		if event.detail != nil {
+			hasDetail = true
+			break
+		}
+	}
+
+	if !hasDetail {
+		names := make([]string, len(events))
+		for i, event := range events {
+			names[i] = event.name
+		}
+		return strings.Join(names, ", ")
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, event := range events {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(event.name)
+		buf.Write(name)
+		buf.WriteByte(':')
+		if event.detail == nil {
+			buf.WriteString("null")
+		} else {
+			buf.Write(event.detail)
+		}
+	}
+	buf.WriteByte('}')
+
+	return buf.String()
+}
+
+// ServerResponse returns the Response installed by ResponseMiddleware for the
+// request, and false if the middleware is not installed.
+func ServerResponse(r *http.Request) (*Response, bool) {
+	res, ok := r.Context().Value(responseContextKey).(*Response)
+	return res, ok
+}
+
+// ServerAddTrigger appends a client-side event to the "HX-Trigger" response
+// header without overwriting the events already set.
+//
+// It queues the event in the Response if ResponseMiddleware is installed, and
+// merges it into the header right away otherwise. The detail is encoded as
+// JSON and can be nil if the event has none.
+//
+// https://htmx.org/headers/hx-trigger/
+func ServerAddTrigger(w http.ResponseWriter, r *http.Request, name string, detail any) error {
+	if res, ok := ServerResponse(r); ok {
+		return res.AddTrigger(name, detail)
+	}
+	return addTriggerHeader(w.Header(), "HX-Trigger", name, detail)
+}
+
+// ServerAddTriggerAfterSettle is the "HX-Trigger-After-Settle" version of
+// ServerAddTrigger.
+//
+// https://htmx.org/headers/hx-trigger/
+func ServerAddTriggerAfterSettle(w http.ResponseWriter, r *http.Request, name string, detail any) error {
+	if res, ok := ServerResponse(r); ok {
+		return res.AddTriggerAfterSettle(name, detail)
+	}
+	return addTriggerHeader(w.Header(), "HX-Trigger-After-Settle", name, detail)
+}
+
+// ServerAddTriggerAfterSwap is the "HX-Trigger-After-Swap" version of
+// ServerAddTrigger.
+//
+// https://htmx.org/headers/hx-trigger/
+func ServerAddTriggerAfterSwap(w http.ResponseWriter, r *http.Request, name string, detail any) error {
+	if res, ok := ServerResponse(r); ok {
+		return res.AddTriggerAfterSwap(name, detail)
+	}
+	return addTriggerHeader(w.Header(), "HX-Trigger-After-Swap", name, detail)
+}
+
+// addTriggerHeader merges a single event into the given HX-Trigger* header.
+func addTriggerHeader(headers http.Header, key string, name string, detail any) error {
+	event, err := newTriggerEvent(name, detail)
+	if err != nil {
+		return err
+	}
+	mergeTriggerHeader(headers, key, []triggerEvent{event})
+
+	return nil
+}
+
+// ResponseMiddleware installs a Response for every request, retrievable with
+// ServerResponse, and wraps the http.ResponseWriter so the accumulated
+// values are merged into the headers right before they are written.
+//
+// Out of band fragments are appended to the body after the handler returns,
+// so handlers that queue them must not set a Content-Length header.
+//
+// Installing the middleware more than once is harmless, the inner instances
+// reuse the outer Response.
+func ResponseMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := ServerResponse(r); ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		res := &Response{}
+		r = r.WithContext(context.WithValue(r.Context(), responseContextKey, res))
+		rw := &responseWriter{ResponseWriter: w, res: res}
+
+		next.ServeHTTP(rw, r)
+		rw.finish(r)
+	})
+}
+
+// responseWriter applies the accumulated Response values before the headers
+// are written.
+type responseWriter struct {
+	http.ResponseWriter
+	res         *Response
+	wroteHeader bool
+	status      int
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if !w.wroteHeader && status >= 200 {
+		w.wroteHeader = true
+		w.status = status
+		w.res.runBeforeHeaders(w.ResponseWriter, status)
+		w.res.applyHeaders(w.Header())
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher if the wrapped writer supports it.
+func (w *responseWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// finish writes the headers if the handler did not, and appends the out of
+// band fragments to the body.
+func (w *responseWriter) finish(r *http.Request) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	nodes := w.res.takeOOB()
+	if len(nodes) == 0 || !responseHasOOB(r, w.status) {
+		return
+	}
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		if err := node.Render(w.ResponseWriter); err != nil {
+			return
+		}
+	}
+}
+
+// responseHasOOB reports whether out of band fragments can be appended to
+// the response.
+func responseHasOOB(r *http.Request, status int) bool {
+	if r.Method == http.MethodHead {
+		return false
+	}
+	if status == http.StatusNoContent || status == http.StatusNotModified {
+		return false
+	}
+	return ServerGetIsHtmxRequest(r.Header) && !ServerGetIsHistoryRestoreRequest(r.Header)
+}
@@ -0,0 +1,239 @@
+package htmx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+func newHtmxRequest(method string, target string) *http.Request {
+	r := httptest.NewRequest(method, target, nil)
+	r.Header.Set("HX-Request", "true")
+	return r
+}
+
+func TestResponseMiddlewareMergesTriggers(t *testing.T) {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServerSetTrigger(w.Header(), "handlerEvent")
+		res, _ := ServerResponse(r)
+		if err := res.AddTrigger("saved", map[string]int{"id": 1}); err != nil {
+			t.Fatal(err)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	outer := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			res, ok := ServerResponse(r)
+			if !ok {
+				t.Fatal("Expected Response to be installed")
+			}
+			if err := res.AddTrigger("flash", nil); err != nil {
+				t.Fatal(err)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	ResponseMiddleware(outer(inner)).ServeHTTP(rec, newHtmxRequest(http.MethodGet, "/"))
+
+	expected := `{"handlerEvent":null,"flash":null,"saved":{"id":1}}`
+	if got := rec.Header().Get("HX-Trigger"); got != expected {
+		t.Errorf("HX-Trigger: expected %q, got %q", expected, got)
+	}
+}
+
+func TestResponseMiddlewareTriggerNamesOnly(t *testing.T) {
+	handler := ResponseMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, _ := ServerResponse(r)
+		_ = res.AddTriggerAfterSettle("first", nil)
+		_ = res.AddTriggerAfterSettle("second", nil)
+		_ = res.AddTriggerAfterSwap("swapped", nil)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newHtmxRequest(http.MethodGet, "/"))
+
+	if got := rec.Header().Get("HX-Trigger-After-Settle"); got != "first, second" {
+		t.Errorf("HX-Trigger-After-Settle: expected %q, got %q", "first, second", got)
+	}
+	if got := rec.Header().Get("HX-Trigger-After-Swap"); got != "swapped" {
+		t.Errorf("HX-Trigger-After-Swap: expected %q, got %q", "swapped", got)
+	}
+	if got := rec.Header().Get("HX-Trigger"); got != "" {
+		t.Errorf("HX-Trigger: expected empty, got %q", got)
+	}
+}
+
+func TestResponseMiddlewareMergesExistingJSONTrigger(t *testing.T) {
+	handler := ResponseMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServerSetTrigger(w.Header(), `{"a":1,"b":{"x":true}}`)
+		res, _ := ServerResponse(r)
+		_ = res.AddTrigger("a", 2)
+		_, _ = w.Write([]byte("ok"))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newHtmxRequest(http.MethodGet, "/"))
+
+	expected := `{"a":2,"b":{"x":true}}`
+	if got := rec.Header().Get("HX-Trigger"); got != expected {
+		t.Errorf("HX-Trigger: expected %q, got %q", expected, got)
+	}
+}
+
+func TestResponseMiddlewareURLs(t *testing.T) {
+	handler := ResponseMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, _ := ServerResponse(r)
+		res.SetPushURL("/first")
+		res.SetPushURL("/second")
+		res.SetReplaceURL("/replaced")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newHtmxRequest(http.MethodGet, "/"))
+
+	if got := rec.Header().Get("HX-Push-Url"); got != "/second" {
+		t.Errorf("HX-Push-Url: expected %q, got %q", "/second", got)
+	}
+	if got := rec.Header().Get("HX-Replace-Url"); got != "/replaced" {
+		t.Errorf("HX-Replace-Url: expected %q, got %q", "/replaced", got)
+	}
+}
+
+func TestResponseMiddlewareOOB(t *testing.T) {
+	handler := ResponseMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, _ := ServerResponse(r)
+		res.AddOOB(nodx.Div(nodx.Id("count"), HxSwapOOB("true"), nodx.Text("3")))
+		_, _ = w.Write([]byte("<p>main</p>"))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newHtmxRequest(http.MethodGet, "/"))
+	expected := `<p>main</p><div id="count" hx-swap-oob="true">3</div>`
+	if got := rec.Body.String(); got != expected {
+		t.Errorf("body: expected %q, got %q", expected, got)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if got := rec.Body.String(); got != "<p>main</p>" {
+		t.Errorf("body: expected OOB to be skipped for non-htmx requests, got %q", got)
+	}
+}
+
+func TestResponseMiddlewareOOBNoContent(t *testing.T) {
+	handler := ResponseMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, _ := ServerResponse(r)
+		res.AddOOB(nodx.Div(HxSwapOOB("true")))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newHtmxRequest(http.MethodDelete, "/"))
+	if rec.Body.Len() != 0 {
+		t.Errorf("body: expected empty, got %q", rec.Body.String())
+	}
+}
+
+func TestResponseMiddlewareNested(t *testing.T) {
+	var outer, inner *Response
+	handler := ResponseMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		outer, _ = ServerResponse(r)
+		ResponseMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			inner, _ = ServerResponse(r)
+		})).ServeHTTP(w, r)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), newHtmxRequest(http.MethodGet, "/"))
+	if outer == nil || outer != inner {
+		t.Error("Expected nested middleware to reuse the outer Response")
+	}
+}
+
+func TestServerResponseNotInstalled(t *testing.T) {
+	if _, ok := ServerResponse(httptest.NewRequest(http.MethodGet, "/", nil)); ok {
+		t.Error("Expected false when the middleware is not installed")
+	}
+}
+
+func TestResponseAddTriggerInvalidDetail(t *testing.T) {
+	res := &Response{}
+	if err := res.AddTrigger("bad", make(chan int)); err == nil {
+		t.Error("Expected an error for a detail that can't be encoded")
+	}
+}
+
+func TestParseTriggerHeader(t *testing.T) {
+	events := parseTriggerHeader(" a , b ,")
+	if len(events) != 2 || events[0].name != "a" || events[1].name != "b" {
+		t.Errorf("unexpected events %+v", events)
+	}
+
+	events = parseTriggerHeader(`{"z":null,"a":"x"}`)
+	if len(events) != 2 || events[0].name != "z" || events[0].detail != nil || string(events[1].detail) != `"x"` {
+		t.Errorf("unexpected events %+v", events)
+	}
+}
+
+func TestServerAddTrigger(t *testing.T) {
+	rec := httptest.NewRecorder()
+	r := newHtmxRequest(http.MethodGet, "/")
+	ServerSetTrigger(rec.Header(), "first")
+	if err := ServerAddTrigger(rec, r, "second", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.Header().Get("HX-Trigger"); got != "first, second" {
+		t.Errorf("HX-Trigger: expected %q, got %q", "first, second", got)
+	}
+
+	handler := ResponseMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := ServerAddTrigger(w, r, "queued", map[string]string{"a": "b"}); err != nil {
+			t.Fatal(err)
+		}
+		if got := w.Header().Get("HX-Trigger"); got != "" {
+			t.Errorf("HX-Trigger: expected the event to be queued, got %q", got)
+		}
+	}))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+	if got := rec.Header().Get("HX-Trigger"); got != `{"queued":{"a":"b"}}` {
+		t.Errorf("HX-Trigger: unexpected value %q", got)
+	}
+}
+
+func TestServerAddTriggerAfterSettle(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if err := ServerAddTriggerAfterSettle(rec, newHtmxRequest(http.MethodGet, "/"), "settled", 1); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.Header().Get("HX-Trigger-After-Settle"); got != `{"settled":1}` {
+		t.Errorf("HX-Trigger-After-Settle: unexpected value %q", got)
+	}
+	if got := rec.Header().Get("HX-Trigger"); got != "" {
+		t.Errorf("HX-Trigger: expected empty, got %q", got)
+	}
+}
+
+func TestServerAddTriggerAfterSwap(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if err := ServerAddTriggerAfterSwap(rec, newHtmxRequest(http.MethodGet, "/"), "swapped", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.Header().Get("HX-Trigger-After-Swap"); got != "swapped" {
+		t.Errorf("HX-Trigger-After-Swap: unexpected value %q", got)
+	}
+
+	handler := ResponseMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := ServerAddTriggerAfterSwap(w, r, "queued", true); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newHtmxRequest(http.MethodGet, "/"))
+	if got := rec.Header().Get("HX-Trigger-After-Swap"); got != `{"queued":true}` {
+		t.Errorf("HX-Trigger-After-Swap: unexpected value %q", got)
+	}
+}
@@ -0,0 +1,63 @@
+package htmx
+
+// SelectorThis is the extended selector that refers to the element the
+// attribute is defined on.
+//
+// https://htmx.org/docs/#extended-css-selectors
+const SelectorThis = "this"
+
+// SelectorDocument is the extended selector that refers to the document.
+//
+// https://htmx.org/docs/#extended-css-selectors
+const SelectorDocument = "document"
+
+// SelectorWindow is the extended selector that refers to the window.
+//
+// https://htmx.org/docs/#extended-css-selectors
+const SelectorWindow = "window"
+
+// SelectorClosest returns a "closest [selector]" extended selector.
+//
+// Finds the closest ancestor element or itself that matches the given CSS selector.
+//
+// https://htmx.org/docs/#extended-css-selectors
+func SelectorClosest(selector string) string {
+	return "closest " + selector
+}
+
+// SelectorFind returns a "find [selector]" extended selector.
+//
+// Finds the first child descendant element that matches the given CSS selector.
+//
+// https://htmx.org/docs/#extended-css-selectors
+func SelectorFind(selector string) string {
+	return "find " + selector
+}
+
+// SelectorNext returns a "next [selector]" extended selector.
+//
+// Scans forward in the DOM for the first element that matches the given CSS
+// selector. If the selector is empty it returns "next", the next element
+// sibling.
+//
+// https://htmx.org/docs/#extended-css-selectors
+func SelectorNext(selector string) string {
+	if selector == "" {
+		return "next"
+	}
+	return "next " + selector
+}
+
+// SelectorPrevious returns a "previous [selector]" extended selector.
+//
+// Scans backwards in the DOM for the first element that matches the given CSS
+// selector. If the selector is empty it returns "previous", the previous
+// element sibling.
+//
+// https://htmx.org/docs/#extended-css-selectors
+func SelectorPrevious(selector string) string {
+	if selector == "" {
+		return "previous"
+	}
+	return "previous " + selector
+}
@@ -0,0 +1,33 @@
+package htmx_test
+
+import (
+	"fmt"
+
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func ExampleSelectorClosest() {
+	fmt.Println(htmx.SelectorClosest("tr"))
+	// Output: closest tr
+}
+
+func ExampleSelectorFind() {
+	fmt.Println(htmx.SelectorFind(".result"))
+	// Output: find .result
+}
+
+func ExampleSelectorNext() {
+	fmt.Println(htmx.SelectorNext("div"))
+	fmt.Println(htmx.SelectorNext(""))
+	// Output:
+	// next div
+	// next
+}
+
+func ExampleSelectorPrevious() {
+	fmt.Println(htmx.SelectorPrevious("div"))
+	fmt.Println(htmx.SelectorPrevious(""))
+	// Output:
+	// previous div
+	// previous
+}
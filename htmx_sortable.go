@@ -0,0 +1,151 @@
+package htmx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// ErrInvalidOrder is returned when a submitted order is not a permutation of
+// the known ids.
+var ErrInvalidOrder = errors.New("invalid order")
+
+// Sortable is a drag and drop sortable list that posts its new order.
+//
+// It renders a form with the "sortable" class, which must be turned into a
+// sortable list on the client, e.g. with Sortable.js. The library must
+// dispatch an "end" event on the form when an item is dropped.
+//
+// https://htmx.org/examples/sortable/
+type Sortable[T any] struct {
+	// ID is the id of the form. Defaults to "sortable".
+	ID string
+
+	// URL is the reorder endpoint, usually served by Handler.
+	URL string
+
+	// Name is the name of the hidden order inputs. Defaults to "order".
+	Name string
+
+	// Key returns the unique key of the item.
+	Key func(item T) string
+
+	// Item renders an item of the list. The input is the hidden order input
+	// and must be rendered inside the item element.
+	Item func(item T, input nodx.Node) nodx.Node
+}
+
+func (s Sortable[T]) id() string {
+	if s.ID == "" {
+		return "sortable"
+	}
+	return s.ID
+}
+
+func (s Sortable[T]) name() string {
+	if s.Name == "" {
+		return "order"
+	}
+	return s.Name
+}
+
+// Render renders the list with the given items, in order.
+//
+// Output: <form id="[ID]" class="sortable" hx-post="[URL]" hx-trigger="end" hx-swap="outerHTML">[items]</form>
+func (s Sortable[T]) Render(items []T) nodx.Node {
+	return nodx.FormEl(
+		nodx.Id(s.id()),
+		nodx.Class("sortable"),
+		HxPost(s.URL),
+		HxTrigger("end"),
+		HxSwap("outerHTML"),
+		nodx.Map(items, func(item T) nodx.Node {
+			return s.Item(item, nodx.Input(
+				nodx.Type("hidden"),
+				nodx.Name(s.name()),
+				nodx.Value(s.Key(item)),
+			))
+		}),
+	)
+}
+
+// ServerReorder returns the items in the order submitted in the request,
+// returning ErrInvalidOrder if it does not contain every item exactly once.
+func (s Sortable[T]) ServerReorder(r *http.Request, items []T) ([]T, error) {
+	byKey := make(map[string]T, len(items))
+	keys := make([]string, len(items))
+	for i, item := range items {
+		keys[i] = s.Key(item)
+		byKey[keys[i]] = item
+	}
+
+	order, err := ServerParseOrder(r, s.name(), keys)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]T, len(order))
+	for i, key := range order {
+		sorted[i] = byKey[key]
+	}
+	return sorted, nil
+}
+
+// Handler returns the handler of the reorder endpoint.
+//
+// It loads the items, reorders them with ServerReorder, saves them and
+// renders the reordered list. Invalid orders get a 400 status code.
+func (s Sortable[T]) Handler(
+	load func(r *http.Request) ([]T, error),
+	save func(r *http.Request, items []T) error,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		items, err := load(r)
+		if err != nil {
+			serverComponentError(w, err)
+			return
+		}
+
+		sorted, err := s.ServerReorder(r, items)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		if err := save(r, sorted); err != nil {
+			serverComponentError(w, err)
+			return
+		}
+		_ = ServerRender(w, s.Render(sorted))
+	})
+}
+
+// ServerParseOrder returns the ids submitted in the form values with the
+// given name, in order.
+//
+// It returns ErrInvalidOrder if they are not a permutation of the known ids,
+// that is, if an id is unknown, repeated or missing.
+func ServerParseOrder(r *http.Request, name string, known []string) ([]string, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOrder, err)
+	}
+	order := r.Form[name]
+
+	remaining := make(map[string]bool, len(known))
+	for _, id := range known {
+		remaining[id] = true
+	}
+	for _, id := range order {
+		if !remaining[id] {
+			return nil, fmt.Errorf("%w: id %q is unknown or repeated", ErrInvalidOrder, id)
+		}
+		delete(remaining, id)
+	}
+	if len(remaining) > 0 || len(order) != len(known) {
+		return nil, fmt.Errorf("%w: %d of %d ids submitted", ErrInvalidOrder, len(order), len(known))
+	}
+
+	return order, nil
+}
@@ -0,0 +1,89 @@
+package htmx_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+var taskList = htmx.Sortable[string]{
+	URL: "/tasks/order",
+	Key: func(task string) string {
+		return task
+	},
+	Item: func(task string, input nodx.Node) nodx.Node {
+		return nodx.Div(input, nodx.Text(task))
+	},
+}
+
+func newOrderRequest(body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/tasks/order", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func ExampleSortable() {
+	fmt.Println(taskList.Render([]string{"a", "b"}))
+	// Output:
+	// <form id="sortable" class="sortable" hx-post="/tasks/order" hx-trigger="end" hx-swap="outerHTML"><div><input type="hidden" name="order" value="a">a</div><div><input type="hidden" name="order" value="b">b</div></form>
+}
+
+func TestServerParseOrder(t *testing.T) {
+	known := []string{"1", "2", "3"}
+
+	tests := []struct {
+		body  string
+		valid bool
+	}{
+		{"order=3&order=1&order=2", true},
+		{"order=3&order=1", false},
+		{"order=3&order=1&order=1", false},
+		{"order=3&order=1&order=2&order=4", false},
+		{"order=3&order=1&order=9", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		order, err := htmx.ServerParseOrder(newOrderRequest(tt.body), "order", known)
+		if tt.valid {
+			if err != nil || strings.Join(order, ",") != "3,1,2" {
+				t.Errorf("%q: unexpected result %v %v", tt.body, order, err)
+			}
+		} else if !errors.Is(err, htmx.ErrInvalidOrder) {
+			t.Errorf("%q: expected ErrInvalidOrder, got %v", tt.body, err)
+		}
+	}
+}
+
+func TestSortableHandler(t *testing.T) {
+	var saved []string
+	handler := taskList.Handler(
+		func(r *http.Request) ([]string, error) {
+			return []string{"a", "b", "c"}, nil
+		},
+		func(r *http.Request, tasks []string) error {
+			saved = tasks
+			return nil
+		},
+	)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newOrderRequest("order=c&order=a&order=b"))
+	if rec.Code != http.StatusOK || strings.Join(saved, ",") != "c,a,b" {
+		t.Errorf("unexpected response %d, saved %v", rec.Code, saved)
+	}
+	if !strings.Contains(rec.Body.String(), `value="c">c</div><div><input type="hidden" name="order" value="a">`) {
+		t.Errorf("expected the reordered list, got %q", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newOrderRequest("order=c"))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,66 @@
+package htmx
+
+import nodx "github.com/nodxdev/nodxgo"
+
+// SyncStrategy is the strategy used by hx-sync to coordinate requests.
+//
+// https://htmx.org/attributes/hx-sync/
+type SyncStrategy string
+
+const (
+	// SyncDrop drops (ignores) the request if a request is already in flight.
+	// This is the default strategy.
+	SyncDrop SyncStrategy = "drop"
+
+	// SyncAbort drops the request if a request is already in flight and, if
+	// another request occurs while this one is in flight, aborts this one.
+	SyncAbort SyncStrategy = "abort"
+
+	// SyncReplace aborts the current request, if any, and replaces it with
+	// the new one.
+	SyncReplace SyncStrategy = "replace"
+
+	// SyncQueue queues the request to run after the one in flight. It is the
+	// same as SyncQueueLast.
+	SyncQueue SyncStrategy = "queue"
+
+	// SyncQueueFirst queues the first request to show up while a request is
+	// in flight.
+	SyncQueueFirst SyncStrategy = "queue first"
+
+	// SyncQueueLast queues the last request to show up while a request is in
+	// flight.
+	SyncQueueLast SyncStrategy = "queue last"
+
+	// SyncQueueAll queues all requests that show up while a request is in
+	// flight.
+	SyncQueueAll SyncStrategy = "queue all"
+)
+
+// SyncValue returns an hx-sync value in the "[selector]:[strategy]" format.
+//
+// The selector can be a CSS selector or any extended selector such as
+// SelectorThis or SelectorClosest("form"). If the selector is empty
+// SelectorThis is used, and if the strategy is empty only the selector is
+// returned, which htmx treats as SyncDrop.
+//
+// https://htmx.org/attributes/hx-sync/
+func SyncValue(selector string, strategy SyncStrategy) string {
+	if selector == "" {
+		selector = SelectorThis
+	}
+	if strategy == "" {
+		return selector
+	}
+	return selector + ":" + string(strategy)
+}
+
+// HxSyncWith renders an hx-sync="[selector]:[strategy]" attribute.
+//
+// It is the typed version of HxSync, see SyncValue for details on how the
+// value is composed.
+//
+// https://htmx.org/attributes/hx-sync/
+func HxSyncWith(selector string, strategy SyncStrategy) nodx.Node {
+	return HxSync(SyncValue(selector, strategy))
+}
@@ -0,0 +1,28 @@
+package htmx_test
+
+import (
+	"fmt"
+
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func ExampleSyncValue() {
+	fmt.Println(htmx.SyncValue(htmx.SelectorClosest("form"), htmx.SyncAbort))
+	fmt.Println(htmx.SyncValue("#search", htmx.SyncQueueLast))
+	fmt.Println(htmx.SyncValue("", htmx.SyncReplace))
+	fmt.Println(htmx.SyncValue("form", ""))
+	// Output:
+	// closest form:abort
+	// #search:queue last
+	// this:replace
+	// form
+}
+
+func ExampleHxSyncWith() {
+	node := nodx.Input(
+		htmx.HxSyncWith(htmx.SelectorThis, htmx.SyncReplace),
+	)
+	fmt.Println(node)
+	// Output: <input hx-sync="this:replace">
+}
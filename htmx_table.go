@@ -0,0 +1,236 @@
+package htmx
+
+import (
+	"net/http"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// TableRowAttrs are the attributes the Table render functions place on the
+// elements of a row.
+type TableRowAttrs struct {
+	// Row goes on the <tr> element.
+	Row nodx.Node
+
+	// Select goes on the selection <input>, making it a checkbox included in
+	// the bulk actions.
+	Select nodx.Node
+
+	// Edit goes on the button that swaps the row for the edit row.
+	Edit nodx.Node
+
+	// Delete goes on the button that deletes the row.
+	Delete nodx.Node
+
+	// Save goes on the button of the edit row that saves its inputs.
+	Save nodx.Node
+
+	// Cancel goes on the button of the edit row that swaps it back for the
+	// row.
+	Cancel nodx.Node
+}
+
+// Table is a table whose rows can be edited inline, deleted, and selected
+// for bulk actions.
+//
+// Row endpoints should respond with ServerRenderRows (GET and PUT of the item
+// URL), ServerRenderEditRow (GET of the item URL followed by "/edit") and
+// ServerRenderDeleted (DELETE of the item URL). Bulk action endpoints should
+// respond with ServerRenderBulk.
+//
+// https://htmx.org/examples/edit-row/
+//
+// https://htmx.org/examples/delete-row/
+//
+// https://htmx.org/examples/bulk-update/
+type Table[T any] struct {
+	// ID is the id of the table. Defaults to "table". The <tbody> id is the
+	// table id followed by "-body", and the row ids are the table id followed
+	// by "-row-" and the item key.
+	ID string
+
+	// Key returns the unique key of the item, used in the row id and as the
+	// selection value.
+	Key func(item T) string
+
+	// URL returns the URL of the item.
+	URL func(item T) string
+
+	// Row renders the row of the item.
+	Row func(item T, attrs TableRowAttrs) nodx.Node
+
+	// EditRow renders the edit row of the item, usually a row with inputs.
+	EditRow func(item T, attrs TableRowAttrs) nodx.Node
+
+	// SelectName is the name of the selection checkboxes, and so of the
+	// parameter listing the selected keys. Defaults to "ids".
+	SelectName string
+
+	// Confirm is the confirmation asked before deleting a row. Defaults to
+	// "Are you sure?".
+	Confirm string
+}
+
+func (t Table[T]) id() string {
+	if t.ID == "" {
+		return "table"
+	}
+	return t.ID
+}
+
+func (t Table[T]) bodyID() string {
+	return t.id() + "-body"
+}
+
+func (t Table[T]) selectName() string {
+	if t.SelectName == "" {
+		return "ids"
+	}
+	return t.SelectName
+}
+
+func (t Table[T]) confirm() string {
+	if t.Confirm == "" {
+		return "Are you sure?"
+	}
+	return t.Confirm
+}
+
+// RowID returns the id of the row of the item.
+func (t Table[T]) RowID(item T) string {
+	return t.id() + "-row-" + t.Key(item)
+}
+
+// rowAttrs returns the attributes of the row of the item.
+func (t Table[T]) rowAttrs(item T, oob bool) TableRowAttrs {
+	url := t.URL(item)
+	target := SelectorClosest("tr")
+
+	return TableRowAttrs{
+		Row: nodx.Group(
+			nodx.Id(t.RowID(item)),
+			nodx.If(oob, HxSwapOOB("true")),
+		),
+		Select: nodx.Group(
+			nodx.Type("checkbox"),
+			nodx.Name(t.selectName()),
+			nodx.Value(t.Key(item)),
+		),
+		Edit: nodx.Group(
+			HxGet(url+"/edit"),
+			HxTarget(target),
+			HxSwap("outerHTML"),
+		),
+		Delete: nodx.Group(
+			HxDelete(url),
+			HxConfirm(t.confirm()),
+			HxTarget(target),
+			HxSwap("outerHTML swap:1s"),
+		),
+		Save: nodx.Group(
+			HxPut(url),
+			HxInclude(target),
+			HxTarget(target),
+			HxSwap("outerHTML"),
+		),
+		Cancel: nodx.Group(
+			HxGet(url),
+			HxTarget(target),
+			HxSwap("outerHTML"),
+		),
+	}
+}
+
+// Render renders the table with the given head, usually a <thead> element,
+// and a row for each item.
+//
+// Output: <table id="[ID]">[head]<tbody id="[ID]-body">[rows]</tbody></table>
+func (t Table[T]) Render(head nodx.Node, items []T) nodx.Node {
+	return nodx.Table(
+		nodx.Id(t.id()),
+		head,
+		nodx.Tbody(
+			nodx.Id(t.bodyID()),
+			t.Rows(items...),
+		),
+	)
+}
+
+// Rows renders the rows of the items.
+func (t Table[T]) Rows(items ...T) nodx.Node {
+	return nodx.Map(items, func(item T) nodx.Node {
+		return t.Row(item, t.rowAttrs(item, false))
+	})
+}
+
+// OOBRows renders the rows of the items as out of band swaps, replacing the
+// rows with the same ids. They are wrapped in a <template> element because
+// table rows are dropped by the HTML parser outside of a table.
+//
+// Output: <template><tr id="[row id]" hx-swap-oob="true">…</tr>…</template>
+//
+// https://htmx.org/attributes/hx-swap-oob/#troublesome-tables-and-lists
+func (t Table[T]) OOBRows(items ...T) nodx.Node {
+	return nodx.Template(nodx.Map(items, func(item T) nodx.Node {
+		return t.Row(item, t.rowAttrs(item, true))
+	}))
+}
+
+// SelectAll renders a checkbox that checks or unchecks the selection
+// checkboxes of every row. Place it in the table head.
+//
+// Output: <input type="checkbox" aria-label="Select all" hx-on:change="…">
+func (t Table[T]) SelectAll() nodx.Node {
+	return nodx.Input(
+		nodx.Type("checkbox"),
+		nodx.Aria("label", "Select all"),
+		HxOn("change", "this.closest('table').querySelectorAll('tbody input[type=checkbox]').forEach(c => c.checked = this.checked)"),
+	)
+}
+
+// BulkAction renders the attributes of an element that sends the selected
+// keys, along with the given request attribute, e.g. HxPost("/bulk").
+//
+// The response is not swapped into a target, it must replace the updated
+// rows with out of band swaps, usually rendered with ServerRenderBulk, so the
+// other rows are left untouched.
+//
+// Output: [request] hx-include="#[ID]-body" hx-swap="none"
+func (t Table[T]) BulkAction(request nodx.Node) nodx.Node {
+	return nodx.Group(
+		request,
+		HxInclude("#"+t.bodyID()),
+		HxSwap("none"),
+	)
+}
+
+// ServerSelected returns the keys of the rows selected for a bulk action.
+func (t Table[T]) ServerSelected(r *http.Request) []string {
+	if err := r.ParseForm(); err != nil {
+		return nil
+	}
+	return r.Form[t.selectName()]
+}
+
+// ServerRenderRows renders the rows of the items, e.g. the saved row.
+func (t Table[T]) ServerRenderRows(w http.ResponseWriter, items ...T) error {
+	return ServerRender(w, t.Rows(items...))
+}
+
+// ServerRenderBulk responds to a BulkAction with the rows of the updated
+// items as out of band swaps, see OOBRows.
+func (t Table[T]) ServerRenderBulk(w http.ResponseWriter, items ...T) error {
+	return ServerRender(w, t.OOBRows(items...))
+}
+
+// ServerRenderEditRow renders the edit row of the item.
+func (t Table[T]) ServerRenderEditRow(w http.ResponseWriter, item T) error {
+	return ServerRender(w, t.EditRow(item, t.rowAttrs(item, false)))
+}
+
+// ServerRenderDeleted responds to a row delete with an empty body and a 200
+// status code, so the row is swapped out. A 204 status code would leave it in
+// place, as htmx does not swap empty responses with that status.
+func ServerRenderDeleted(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusOK)
+}
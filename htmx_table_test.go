@@ -0,0 +1,103 @@
+package htmx_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func newContactTable() htmx.Table[contact] {
+	return htmx.Table[contact]{
+		ID: "contacts",
+		Key: func(c contact) string {
+			return strconv.Itoa(c.ID)
+		},
+		URL: func(c contact) string {
+			return fmt.Sprintf("/contacts/%d", c.ID)
+		},
+		Row: func(c contact, attrs htmx.TableRowAttrs) nodx.Node {
+			return nodx.Tr(
+				attrs.Row,
+				nodx.Td(nodx.Input(attrs.Select)),
+				nodx.Td(nodx.Text(c.Name)),
+				nodx.Td(nodx.Button(attrs.Delete, nodx.Text("Delete"))),
+			)
+		},
+		EditRow: func(c contact, attrs htmx.TableRowAttrs) nodx.Node {
+			return nodx.Tr(
+				attrs.Row,
+				nodx.Td(nodx.Input(nodx.Name("name"), nodx.Value(c.Name))),
+				nodx.Td(nodx.Button(attrs.Save, nodx.Text("Save"))),
+			)
+		},
+	}
+}
+
+func ExampleTable() {
+	table := newContactTable()
+	fmt.Println(table.Render(nodx.Thead(nodx.Tr(nodx.Th(table.SelectAll()))), []contact{{ID: 1, Name: "Ada"}}))
+	fmt.Println(nodx.Button(table.BulkAction(htmx.HxPost("/contacts/archive")), nodx.Text("Archive")))
+	// Output:
+	// <table id="contacts"><thead><tr><th><input type="checkbox" aria-label="Select all" hx-on:change="this.closest(&#39;table&#39;).querySelectorAll(&#39;tbody input[type=checkbox]&#39;).forEach(c =&gt; c.checked = this.checked)"></th></tr></thead><tbody id="contacts-body"><tr id="contacts-row-1"><td><input type="checkbox" name="ids" value="1"></td><td>Ada</td><td><button hx-delete="/contacts/1" hx-confirm="Are you sure?" hx-target="closest tr" hx-swap="outerHTML swap:1s">Delete</button></td></tr></tbody></table>
+	// <button hx-post="/contacts/archive" hx-include="#contacts-body" hx-swap="none">Archive</button>
+}
+
+func ExampleTable_OOBRows() {
+	table := newContactTable()
+	fmt.Println(table.OOBRows(contact{ID: 2, Name: "Grace"}))
+	// Output:
+	// <template><tr id="contacts-row-2" hx-swap-oob="true"><td><input type="checkbox" name="ids" value="2"></td><td>Grace</td><td><button hx-delete="/contacts/2" hx-confirm="Are you sure?" hx-target="closest tr" hx-swap="outerHTML swap:1s">Delete</button></td></tr></template>
+}
+
+func TestTableServerRenderEditRow(t *testing.T) {
+	table := newContactTable()
+	rec := httptest.NewRecorder()
+	if err := table.ServerRenderEditRow(rec, contact{ID: 1, Name: "Ada"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<button hx-put="/contacts/1" hx-include="closest tr" hx-target="closest tr" hx-swap="outerHTML">Save</button>`
+	if got := rec.Body.String(); !strings.Contains(got, expected) {
+		t.Errorf("expected body to contain %q, got %q", expected, got)
+	}
+}
+
+func TestTableServerSelected(t *testing.T) {
+	table := newContactTable()
+	r := httptest.NewRequest(http.MethodPost, "/contacts/archive", strings.NewReader("ids=1&ids=3&name=x"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	got := table.ServerSelected(r)
+	if len(got) != 2 || got[0] != "1" || got[1] != "3" {
+		t.Errorf("unexpected selection %v", got)
+	}
+}
+
+func TestServerRenderDeleted(t *testing.T) {
+	rec := httptest.NewRecorder()
+	htmx.ServerRenderDeleted(rec)
+	if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Errorf("expected an empty 200 response, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestTableServerRenderBulk(t *testing.T) {
+	table := newContactTable()
+	rec := httptest.NewRecorder()
+	if err := table.ServerRenderBulk(rec, contact{ID: 1, Name: "Ada"}, contact{ID: 3, Name: "Grace"}); err != nil {
+		t.Fatal(err)
+	}
+
+	body := rec.Body.String()
+	if !strings.HasPrefix(body, `<template><tr id="contacts-row-1" hx-swap-oob="true">`) ||
+		!strings.Contains(body, `<tr id="contacts-row-3" hx-swap-oob="true">`) ||
+		!strings.HasSuffix(body, "</template>") {
+		t.Errorf("expected the rows as out of band swaps, got %q", body)
+	}
+}
@@ -0,0 +1,117 @@
+package htmx
+
+import (
+	"net/http"
+	"strconv"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// Tab is a tab of a Tabs component.
+type Tab struct {
+	// ID identifies the tab, it must be unique within its Tabs.
+	ID string
+
+	// Label is the text of the tab.
+	Label string
+
+	// URL is the page of the tab. It is loaded into the panel and pushed to
+	// the browser history, so it must also render the full page with the tab
+	// selected, see Tabs.ServerRender.
+	URL string
+}
+
+// Tabs is a tab list whose tabs load their content into a shared panel and
+// push their URL to the browser history, so tabs can be bookmarked and
+// reloaded.
+//
+// Every tab URL renders the whole component with its tab selected. The tabs
+// select the component from the response with hx-select, so they work
+// whether the response is the component alone or a full page.
+//
+// https://htmx.org/examples/tabs-hateoas/
+type Tabs struct {
+	// ID is the id of the component. Defaults to "tabs". The tab ids are the
+	// component id followed by "-tab-" and the tab id, and the panel id is
+	// the component id followed by "-panel".
+	ID string
+
+	// Tabs are the tabs, in order.
+	Tabs []Tab
+}
+
+func (t Tabs) id() string {
+	if t.ID == "" {
+		return "tabs"
+	}
+	return t.ID
+}
+
+func (t Tabs) panelID() string {
+	return t.id() + "-panel"
+}
+
+func (t Tabs) tabID(tab Tab) string {
+	return t.id() + "-tab-" + tab.ID
+}
+
+// Render renders the tab list, with the tab with the given id selected, and
+// the panel with the given content.
+//
+// Output: <div id="[ID]"><div role="tablist" hx-target="#[ID]" hx-select="#[ID]" hx-swap="outerHTML" hx-push-url="true"><a role="tab" …>[Label]</a>…</div><div id="[ID]-panel" role="tabpanel" …>[panel]</div></div>
+func (t Tabs) Render(active string, panel nodx.Node) nodx.Node {
+	var activeTab Tab
+	for _, tab := range t.Tabs {
+		if tab.ID == active {
+			activeTab = tab
+		}
+	}
+
+	return nodx.Div(
+		nodx.Id(t.id()),
+		nodx.Div(
+			nodx.Role("tablist"),
+			HxTarget("#"+t.id()),
+			HxSelect("#"+t.id()),
+			HxSwap("outerHTML"),
+			HxPushURLEnabled(true),
+			nodx.Map(t.Tabs, func(tab Tab) nodx.Node {
+				return nodx.A(
+					nodx.Id(t.tabID(tab)),
+					nodx.Href(tab.URL),
+					HxGet(tab.URL),
+					nodx.Role("tab"),
+					nodx.Aria("selected", strconv.FormatBool(tab.ID == active)),
+					nodx.Aria("controls", t.panelID()),
+					nodx.Text(tab.Label),
+				)
+			}),
+		),
+		nodx.Div(
+			nodx.Id(t.panelID()),
+			nodx.Role("tabpanel"),
+			nodx.If(activeTab.ID != "", nodx.Aria("labelledby", t.tabID(activeTab))),
+			panel,
+		),
+	)
+}
+
+// ServerRender renders the component with the tab with the given id
+// selected and the given panel content.
+//
+// Tab clicks get the component alone, while regular, boosted and history
+// restore requests get the full page, built by wrapping the component with
+// the page function. See Request.WantsFullPage.
+func (t Tabs) ServerRender(
+	w http.ResponseWriter,
+	r *http.Request,
+	active string,
+	panel nodx.Node,
+	page func(tabs nodx.Node) nodx.Node,
+) error {
+	tabs := t.Render(active, panel)
+	if ServerParseRequest(r.Header).WantsFullPage() {
+		return ServerRender(w, page(tabs))
+	}
+	return ServerRender(w, tabs)
+}
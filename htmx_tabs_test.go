@@ -0,0 +1,61 @@
+package htmx_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+var settingsTabs = htmx.Tabs{
+	ID: "settings",
+	Tabs: []htmx.Tab{
+		{ID: "profile", Label: "Profile", URL: "/settings/profile"},
+		{ID: "security", Label: "Security", URL: "/settings/security"},
+	},
+}
+
+func ExampleTabs() {
+	fmt.Println(settingsTabs.Render("security", nodx.P(nodx.Text("Password"))))
+	// Output:
+	// <div id="settings"><div role="tablist" hx-target="#settings" hx-select="#settings" hx-swap="outerHTML" hx-push-url="true"><a id="settings-tab-profile" href="/settings/profile" hx-get="/settings/profile" role="tab" aria-selected="false" aria-controls="settings-panel">Profile</a><a id="settings-tab-security" href="/settings/security" hx-get="/settings/security" role="tab" aria-selected="true" aria-controls="settings-panel">Security</a></div><div id="settings-panel" role="tabpanel" aria-labelledby="settings-tab-security"><p>Password</p></div></div>
+}
+
+func TestTabsServerRender(t *testing.T) {
+	page := func(tabs nodx.Node) nodx.Node {
+		return nodx.Main(tabs)
+	}
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		fullPage bool
+	}{
+		{"regular", nil, true},
+		{"tab click", map[string]string{"HX-Request": "true", "HX-Target": "settings"}, false},
+		{"boosted", map[string]string{"HX-Request": "true", "HX-Boosted": "true"}, true},
+		{"history restore", map[string]string{"HX-Request": "true", "HX-History-Restore-Request": "true"}, true},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/settings/profile", nil)
+		for key, value := range tt.headers {
+			r.Header.Set(key, value)
+		}
+		rec := httptest.NewRecorder()
+		if err := settingsTabs.ServerRender(rec, r, "profile", nodx.Text("Name"), page); err != nil {
+			t.Fatal(err)
+		}
+
+		body := rec.Body.String()
+		if got := strings.HasPrefix(body, "<main>"); got != tt.fullPage {
+			t.Errorf("%s: expected full page %v, got %q", tt.name, tt.fullPage, body)
+		}
+		if !strings.Contains(body, `<div id="settings">`) {
+			t.Errorf("%s: expected the tabs to be rendered, got %q", tt.name, body)
+		}
+	}
+}
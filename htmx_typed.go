@@ -0,0 +1,127 @@
+package htmx
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// EncodingMultipart is the multipart/form-data request encoding, required
+// for file uploads.
+const EncodingMultipart = "multipart/form-data"
+
+// formatDuration formats a duration as an htmx time interval, in seconds if
+// it is a whole number of seconds and in milliseconds otherwise. Fractions of
+// a millisecond are rounded up so a positive duration never renders as 0ms.
+//
+// https://htmx.org/docs/#parameters
+func formatDuration(d time.Duration) string {
+	if d%time.Second == 0 {
+		return strconv.FormatInt(int64(d/time.Second), 10) + "s"
+	}
+	ms := (d + time.Millisecond - 1) / time.Millisecond
+	return strconv.FormatInt(int64(ms), 10) + "ms"
+}
+
+// HxParamsAll renders an hx-params="*" attribute.
+//
+// Includes all parameters in the request (the default).
+//
+// https://htmx.org/attributes/hx-params/
+func HxParamsAll() nodx.Node {
+	return HxParams("*")
+}
+
+// HxParamsNone renders an hx-params="none" attribute.
+//
+// Includes no parameters in the request.
+//
+// https://htmx.org/attributes/hx-params/
+func HxParamsNone() nodx.Node {
+	return HxParams("none")
+}
+
+// HxParamsInclude renders an hx-params="[name],[name],…" attribute.
+//
+// Includes only the given parameters in the request. If no names are given
+// it renders hx-params="none".
+//
+// https://htmx.org/attributes/hx-params/
+func HxParamsInclude(names ...string) nodx.Node {
+	if len(names) == 0 {
+		return HxParamsNone()
+	}
+	return HxParams(strings.Join(names, ","))
+}
+
+// HxParamsExclude renders an hx-params="not [name],[name],…" attribute.
+//
+// Includes all parameters in the request except the given ones. If no names
+// are given it renders hx-params="*".
+//
+// https://htmx.org/attributes/hx-params/
+func HxParamsExclude(names ...string) nodx.Node {
+	if len(names) == 0 {
+		return HxParamsAll()
+	}
+	return HxParams("not " + strings.Join(names, ","))
+}
+
+// HxEncodingMultipart renders an hx-encoding="multipart/form-data" attribute.
+//
+// Changes the request encoding type to multipart/form-data to allow file
+// uploads.
+//
+// https://htmx.org/attributes/hx-encoding/
+func HxEncodingMultipart() nodx.Node {
+	return HxEncoding(EncodingMultipart)
+}
+
+// HxPushURLEnabled renders an hx-push-url="true|false" attribute.
+//
+// If enabled, pushes the fetched URL into the browser history. Use HxPushURL
+// to push a specific URL instead.
+//
+// https://htmx.org/attributes/hx-push-url/
+func HxPushURLEnabled(enabled bool) nodx.Node {
+	return HxPushURL(strconv.FormatBool(enabled))
+}
+
+// HxReplaceURLEnabled renders an hx-replace-url="true|false" attribute.
+//
+// If enabled, replaces the current URL in the location bar with the fetched
+// URL. Use HxReplaceURL to replace it with a specific URL instead.
+//
+// https://htmx.org/attributes/hx-replace-url/
+func HxReplaceURLEnabled(enabled bool) nodx.Node {
+	return HxReplaceURL(strconv.FormatBool(enabled))
+}
+
+// HxBoostEnabled renders an hx-boost="true|false" attribute.
+//
+// Enables or disables progressive enhancement for links and forms.
+//
+// https://htmx.org/attributes/hx-boost/
+func HxBoostEnabled(enabled bool) nodx.Node {
+	return HxBoost(strconv.FormatBool(enabled))
+}
+
+// HxValidateEnabled renders an hx-validate="true|false" attribute.
+//
+// Enables or disables validation of the element before a request.
+//
+// https://htmx.org/attributes/hx-validate/
+func HxValidateEnabled(enabled bool) nodx.Node {
+	return HxValidate(strconv.FormatBool(enabled))
+}
+
+// HxHistoryEnabled renders an hx-history="true|false" attribute.
+//
+// If disabled, prevents the page from being saved to the history cache.
+//
+// https://htmx.org/attributes/hx-history/
+func HxHistoryEnabled(enabled bool) nodx.Node {
+	return HxHistory(strconv.FormatBool(enabled))
+}
@@ -0,0 +1,88 @@
+package htmx_test
+
+import (
+	"fmt"
+
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func ExampleHxParamsAll() {
+	node := nodx.Div(
+		htmx.HxParamsAll(),
+	)
+	fmt.Println(node)
+	// Output: <div hx-params="*"></div>
+}
+
+func ExampleHxParamsNone() {
+	node := nodx.Div(
+		htmx.HxParamsNone(),
+	)
+	fmt.Println(node)
+	// Output: <div hx-params="none"></div>
+}
+
+func ExampleHxParamsInclude() {
+	fmt.Println(nodx.Div(htmx.HxParamsInclude("a", "b")))
+	fmt.Println(nodx.Div(htmx.HxParamsInclude()))
+	// Output:
+	// <div hx-params="a,b"></div>
+	// <div hx-params="none"></div>
+}
+
+func ExampleHxParamsExclude() {
+	fmt.Println(nodx.Div(htmx.HxParamsExclude("a", "b")))
+	fmt.Println(nodx.Div(htmx.HxParamsExclude()))
+	// Output:
+	// <div hx-params="not a,b"></div>
+	// <div hx-params="*"></div>
+}
+
+func ExampleHxEncodingMultipart() {
+	node := nodx.FormEl(
+		htmx.HxEncodingMultipart(),
+	)
+	fmt.Println(node)
+	// Output: <form hx-encoding="multipart/form-data"></form>
+}
+
+func ExampleHxPushURLEnabled() {
+	node := nodx.Div(
+		htmx.HxPushURLEnabled(true),
+	)
+	fmt.Println(node)
+	// Output: <div hx-push-url="true"></div>
+}
+
+func ExampleHxReplaceURLEnabled() {
+	node := nodx.Div(
+		htmx.HxReplaceURLEnabled(false),
+	)
+	fmt.Println(node)
+	// Output: <div hx-replace-url="false"></div>
+}
+
+func ExampleHxBoostEnabled() {
+	node := nodx.Div(
+		htmx.HxBoostEnabled(true),
+	)
+	fmt.Println(node)
+	// Output: <div hx-boost="true"></div>
+}
+
+func ExampleHxValidateEnabled() {
+	node := nodx.FormEl(
+		htmx.HxValidateEnabled(true),
+	)
+	fmt.Println(node)
+	// Output: <form hx-validate="true"></form>
+}
+
+func ExampleHxHistoryEnabled() {
+	node := nodx.Div(
+		htmx.HxHistoryEnabled(false),
+	)
+	fmt.Println(node)
+	// Output: <div hx-history="false"></div>
+}
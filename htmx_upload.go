@@ -0,0 +1,290 @@
+package htmx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// UploadedFile is a file received by Upload.ServerReceive.
+type UploadedFile struct {
+	// Filename is the name of the file on the client.
+	Filename string
+
+	// ContentType is the content type sent by the client, it is not verified.
+	ContentType string
+
+	// Size is the size of the file in bytes.
+	Size int64
+
+	// Location is where the storage saved the file.
+	Location string
+}
+
+// UploadStorage saves the files received by Upload.ServerReceive.
+type UploadStorage interface {
+	// Store saves the content of the file and returns its location, e.g. a
+	// path or an object key. The file size is not known yet.
+	//
+	// Reading the content fails if the file exceeds the maximum size, in
+	// which case Store must discard what it saved and return the error.
+	Store(ctx context.Context, file UploadedFile, content io.Reader) (string, error)
+
+	// Remove deletes a stored file. It is called for the files already
+	// stored when a later file of the same request fails.
+	Remove(ctx context.Context, location string) error
+}
+
+// Upload is a file upload form that shows the upload progress and streams
+// the files to an UploadStorage, without buffering them in memory or on
+// disk.
+//
+// https://htmx.org/examples/file-upload/
+type Upload struct {
+	// ID is the id of the form. Defaults to "upload". The progress bar id is
+	// the form id followed by "-progress", and the result container id is the
+	// form id followed by "-result".
+	ID string
+
+	// URL is the upload endpoint, usually served by Handler.
+	URL string
+
+	// Name is the name of the file input. Defaults to "file".
+	Name string
+
+	// Accept, if set, restricts the file types the user can choose, e.g.
+	// "image/*".
+	Accept string
+
+	// MaxFileSize is the maximum size of a file in bytes. Defaults to 10 MiB.
+	MaxFileSize int64
+
+	// MaxFiles is the maximum number of files. Defaults to 1, more than one
+	// allows multiple files to be chosen.
+	MaxFiles int
+}
+
+func (u Upload) id() string {
+	if u.ID == "" {
+		return "upload"
+	}
+	return u.ID
+}
+
+func (u Upload) name() string {
+	if u.Name == "" {
+		return "file"
+	}
+	return u.Name
+}
+
+func (u Upload) maxFileSize() int64 {
+	if u.MaxFileSize <= 0 {
+		return 10 << 20
+	}
+	return u.MaxFileSize
+}
+
+func (u Upload) maxFiles() int {
+	if u.MaxFiles <= 0 {
+		return 1
+	}
+	return u.MaxFiles
+}
+
+// maxRequestSize is the maximum size of the whole request body: the maximum
+// size of every file plus 1 MiB for the multipart headers and the other form
+// fields.
+func (u Upload) maxRequestSize() int64 {
+	const overhead = 1 << 20
+	files := u.maxFileSize()
+	if files > (math.MaxInt64-overhead)/int64(u.maxFiles()) {
+		return math.MaxInt64
+	}
+	return files*int64(u.maxFiles()) + overhead
+}
+
+// Form renders the upload form with the given children, usually a submit
+// button. The response is swapped into the Result container.
+//
+// Output: <form id="[ID]" hx-post="[URL]" hx-encoding="multipart/form-data" hx-target="#[ID]-result" hx-on:htmx:xhr:progress="…"><input type="file" name="[Name]" …>[children]<progress id="[ID]-progress" value="0" max="100"></progress></form>
+func (u Upload) Form(children ...nodx.Node) nodx.Node {
+	progressID := u.id() + "-progress"
+
+	return nodx.FormEl(
+		nodx.Id(u.id()),
+		HxPost(u.URL),
+		HxEncodingMultipart(),
+		HxTarget("#"+u.id()+"-result"),
+		HxOn(
+			"htmx:xhr:progress",
+			"document.getElementById('"+progressID+"').value = event.detail.loaded / event.detail.total * 100",
+		),
+		nodx.Input(
+			nodx.Type("file"),
+			nodx.Name(u.name()),
+			nodx.If(u.Accept != "", nodx.Accept(u.Accept)),
+			nodx.If(u.maxFiles() > 1, nodx.Multiple("")),
+		),
+		nodx.Group(children...),
+		nodx.Progress(nodx.Id(progressID), nodx.Value("0"), nodx.Max("100")),
+	)
+}
+
+// Result renders the container of the upload response with the given
+// initial children.
+//
+// Output: <div id="[ID]-result" aria-live="polite">[children]</div>
+func (u Upload) Result(children ...nodx.Node) nodx.Node {
+	return nodx.Div(
+		nodx.Id(u.id()+"-result"),
+		nodx.Aria("live", "polite"),
+		nodx.Group(children...),
+	)
+}
+
+// errFileTooLarge is returned to the storage when a file exceeds the maximum
+// size.
+var errFileTooLarge = errors.New("file too large")
+
+// uploadReader counts the bytes read from a file and fails once more than
+// the maximum were read.
+type uploadReader struct {
+	r        io.Reader
+	max      int64
+	read     int64
+	exceeded bool
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	if r.exceeded {
+		return 0, errFileTooLarge
+	}
+	if remaining := r.max - r.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	n, err := r.r.Read(p)
+	r.read += int64(n)
+	if r.read > r.max {
+		r.exceeded = true
+		return n, errFileTooLarge
+	}
+	return n, err
+}
+
+// ServerReceive streams the files of the request to the storage, one at a
+// time, and returns them.
+//
+// It returns ValidationErrors, keyed by Name, when the request is not a
+// multipart form, has no file, has too many files, a file exceeds
+// MaxFileSize, or the whole body exceeds the size of MaxFiles files plus
+// 1 MiB for the other form fields. Other errors come from the storage. In
+// both cases the files stored so far are removed from the storage.
+func (u Upload) ServerReceive(w http.ResponseWriter, r *http.Request, storage UploadStorage) ([]UploadedFile, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, u.maxRequestSize())
+
+	files, err := u.receive(r, storage)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		err = ValidationErrors{u.name(): fmt.Sprintf("must be at most %d bytes in total", tooLarge.Limit)}
+	}
+	if err != nil {
+		for _, file := range files {
+			_ = storage.Remove(r.Context(), file.Location)
+		}
+		return nil, err
+	}
+	return files, nil
+}
+
+// receive streams the files of the request to the storage and returns the
+// ones stored, even on failure.
+func (u Upload) receive(r *http.Request, storage UploadStorage) ([]UploadedFile, error) {
+	name := u.name()
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, ValidationErrors{name: "must be sent as a multipart form"}
+	}
+
+	var files []UploadedFile
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return files, err
+			}
+			return files, ValidationErrors{name: "could not be read"}
+		}
+		if part.FormName() != name || part.FileName() == "" {
+			continue
+		}
+		if len(files) == u.maxFiles() {
+			return files, ValidationErrors{name: fmt.Sprintf("must be at most %d files", u.maxFiles())}
+		}
+
+		file := UploadedFile{
+			Filename:    part.FileName(),
+			ContentType: part.Header.Get("Content-Type"),
+		}
+		content := &uploadReader{r: part, max: u.maxFileSize()}
+		location, err := storage.Store(r.Context(), file, content)
+		if (err != nil || content.exceeded) && location != "" {
+			_ = storage.Remove(r.Context(), location)
+		}
+		if content.exceeded {
+			return files, ValidationErrors{name: fmt.Sprintf("must be at most %d bytes", u.maxFileSize())}
+		}
+		if err != nil {
+			return files, fmt.Errorf("failed to store %q: %w", file.Filename, err)
+		}
+
+		file.Size = content.read
+		file.Location = location
+		files = append(files, file)
+	}
+
+	if len(files) == 0 {
+		return nil, ValidationErrors{name: "is required"}
+	}
+	return files, nil
+}
+
+// Handler returns the handler of the upload endpoint.
+//
+// It receives the files with ServerReceive and renders the fragment returned
+// by render, which gets either the uploaded files or the validation errors
+// and can return nil to render nothing. Both are rendered with a 200 status
+// code so htmx swaps them.
+func (u Upload) Handler(
+	storage UploadStorage,
+	render func(r *http.Request, files []UploadedFile, errs ValidationErrors) (nodx.Node, error),
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		files, err := u.ServerReceive(w, r, storage)
+		errs, err := validationErrors(err)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		node, err := render(r, files, errs)
+		if err != nil {
+			serverComponentError(w, err)
+			return
+		}
+		if node == nil {
+			node = nodx.Group()
+		}
+		_ = ServerRender(w, node)
+	})
+}
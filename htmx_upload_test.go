@@ -0,0 +1,198 @@
+package htmx_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+type memoryStorage struct {
+	files map[string][]byte
+}
+
+func (s *memoryStorage) Store(ctx context.Context, file htmx.UploadedFile, content io.Reader) (string, error) {
+	b, err := io.ReadAll(content)
+	if err != nil {
+		return "", err
+	}
+	if s.files == nil {
+		s.files = map[string][]byte{}
+	}
+	s.files[file.Filename] = b
+	return "mem/" + file.Filename, nil
+}
+
+func (s *memoryStorage) Remove(ctx context.Context, location string) error {
+	delete(s.files, location[len("mem/"):])
+	return nil
+}
+
+func newUploadRequest(t *testing.T, files map[string]string) *http.Request {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	if err := mw.WriteField("title", "ignored"); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		fw, err := mw.CreateFormFile("file", name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = fw.Write([]byte(content))
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/upload", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	return r
+}
+
+func ExampleUpload() {
+	upload := htmx.Upload{URL: "/upload", Accept: "image/*", MaxFiles: 5}
+	fmt.Println(upload.Form(nodx.Button(nodx.Text("Upload"))))
+	fmt.Println(upload.Result())
+	// Output:
+	// <form id="upload" hx-post="/upload" hx-encoding="multipart/form-data" hx-target="#upload-result" hx-on:htmx:xhr:progress="document.getElementById(&#39;upload-progress&#39;).value = event.detail.loaded / event.detail.total * 100"><input type="file" name="file" accept="image/*" multiple=""><button>Upload</button><progress id="upload-progress" value="0" max="100"></progress></form>
+	// <div id="upload-result" aria-live="polite"></div>
+}
+
+func TestUploadServerReceive(t *testing.T) {
+	upload := htmx.Upload{MaxFileSize: 5, MaxFiles: 2}
+
+	tests := []struct {
+		name   string
+		files  map[string]string
+		err    string
+		stored int
+	}{
+		{"ok", map[string]string{"a.txt": "hello", "b.txt": "hi"}, "", 2},
+		{"too large", map[string]string{"a.txt": "hello!"}, "file: must be at most 5 bytes", 0},
+		{"too many", map[string]string{"a.txt": "a", "b.txt": "b", "c.txt": "c"}, "file: must be at most 2 files", 0},
+		{"missing", nil, "file: is required", 0},
+	}
+	for _, tt := range tests {
+		storage := &memoryStorage{}
+		files, err := upload.ServerReceive(httptest.NewRecorder(), newUploadRequest(t, tt.files), storage)
+
+		if tt.err == "" {
+			if err != nil {
+				t.Fatalf("%s: unexpected error %v", tt.name, err)
+			}
+			for _, file := range files {
+				if file.Size != int64(len(tt.files[file.Filename])) || file.Location != "mem/"+file.Filename {
+					t.Errorf("%s: unexpected file %+v", tt.name, file)
+				}
+			}
+		} else {
+			var errs htmx.ValidationErrors
+			if !errors.As(err, &errs) || err.Error() != tt.err {
+				t.Errorf("%s: expected validation error %q, got %v", tt.name, tt.err, err)
+			}
+		}
+		if len(files) != tt.stored || len(storage.files) != tt.stored {
+			t.Errorf("%s: expected %d stored files, got %d returned and %d stored", tt.name, tt.stored, len(files), len(storage.files))
+		}
+	}
+}
+
+func TestUploadServerReceiveNotMultipart(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/upload", nil)
+	_, err := (htmx.Upload{}).ServerReceive(httptest.NewRecorder(), r, &memoryStorage{})
+	if err == nil || err.Error() != "file: must be sent as a multipart form" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestUploadHandler(t *testing.T) {
+	handler := (htmx.Upload{}).Handler(&memoryStorage{}, func(r *http.Request, files []htmx.UploadedFile, errs htmx.ValidationErrors) (nodx.Node, error) {
+		if errs != nil {
+			return nodx.P(nodx.Text(errs.Error())), nil
+		}
+		return nodx.P(nodx.Textf("%d uploaded", len(files))), nil
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newUploadRequest(t, map[string]string{"a.txt": "a"}))
+	if rec.Code != http.StatusOK || rec.Body.String() != "<p>1 uploaded</p>" {
+		t.Errorf("unexpected response %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newUploadRequest(t, nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "<p>file: is required</p>" {
+		t.Errorf("unexpected response %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+// sloppyStorage ignores read errors, so it stores truncated files.
+type sloppyStorage struct {
+	memoryStorage
+}
+
+func (s *sloppyStorage) Store(ctx context.Context, file htmx.UploadedFile, content io.Reader) (string, error) {
+	_, _ = io.Copy(io.Discard, content)
+	return s.memoryStorage.Store(ctx, file, strings.NewReader("partial"))
+}
+
+func TestUploadServerReceiveRemovesOversizedFile(t *testing.T) {
+	storage := &sloppyStorage{}
+	upload := htmx.Upload{MaxFileSize: 5}
+	_, err := upload.ServerReceive(httptest.NewRecorder(), newUploadRequest(t, map[string]string{"a.txt": "hello!"}), storage)
+	if err == nil || err.Error() != "file: must be at most 5 bytes" {
+		t.Errorf("unexpected error %v", err)
+	}
+	if len(storage.files) != 0 {
+		t.Errorf("expected the oversized file to be removed, got %v", storage.files)
+	}
+}
+
+func TestUploadServerReceiveRequestSizeLimit(t *testing.T) {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	if err := mw.WriteField("other", strings.Repeat("a", 2<<20)); err != nil {
+		t.Fatal(err)
+	}
+	fw, err := mw.CreateFormFile("file", "a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = fw.Write([]byte("a"))
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest(http.MethodPost, "/upload", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+
+	storage := &memoryStorage{}
+	_, err = (htmx.Upload{MaxFileSize: 5}).ServerReceive(httptest.NewRecorder(), r, storage)
+	if err == nil || err.Error() != "file: must be at most 1048581 bytes in total" {
+		t.Errorf("unexpected error %v", err)
+	}
+	if len(storage.files) != 0 {
+		t.Errorf("expected no stored files, got %v", storage.files)
+	}
+}
+
+func TestUploadHandlerNilNode(t *testing.T) {
+	handler := (htmx.Upload{}).Handler(&memoryStorage{}, func(r *http.Request, files []htmx.UploadedFile, errs htmx.ValidationErrors) (nodx.Node, error) {
+		return nil, nil
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newUploadRequest(t, map[string]string{"a.txt": "a"}))
+	if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Errorf("expected an empty response, got %d %q", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,49 @@
+package htmx
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// ValidationErrors maps form field names to their validation error
+// messages.
+//
+// Handlers that re-render a form with its errors should respond with a 200
+// status code, since htmx does not swap error responses by default.
+type ValidationErrors map[string]string
+
+// Error returns the errors sorted by field name, separated by semicolons.
+func (e ValidationErrors) Error() string {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = field + ": " + e[field]
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Has returns true if the field has an error.
+func (e ValidationErrors) Has(field string) bool {
+	_, ok := e[field]
+	return ok
+}
+
+// validationErrors splits err into its ValidationErrors, if it has any, and
+// the remaining error. Empty ValidationErrors, such as a nil one returned as
+// an error, are not a failure, so both results are nil.
+func validationErrors(err error) (ValidationErrors, error) {
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		return nil, err
+	}
+	if len(errs) == 0 {
+		return nil, nil
+	}
+	return errs, nil
+}
@@ -0,0 +1,33 @@
+package htmx_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func ExampleValidationErrors() {
+	errs := htmx.ValidationErrors{
+		"name":  "is required",
+		"email": "is invalid",
+	}
+	fmt.Println(errs.Error())
+	fmt.Println(errs.Has("name"), errs.Has("phone"))
+	// Output:
+	// email: is invalid; name: is required
+	// true false
+}
+
+func TestValidationErrorsAs(t *testing.T) {
+	var err error = fmt.Errorf("save: %w", htmx.ValidationErrors{"name": "is required"})
+
+	var errs htmx.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatal("Expected errors.As to find the ValidationErrors")
+	}
+	if errs["name"] != "is required" {
+		t.Errorf("unexpected errors %v", errs)
+	}
+}
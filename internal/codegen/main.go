@@ -0,0 +1,208 @@
+// Command codegen generates the htmx attribute helpers, server header helpers,
+// their tests, the attribute registry and the reference docs from spec.json.
+//
+// Run it from the repository root with:
+//
+//	go generate ./...
+package main
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+// Spec is the machine-readable description of the htmx attributes and headers.
+type Spec struct {
+	Attributes      []Attribute      `json:"attributes"`
+	RequestHeaders  []RequestHeader  `json:"request_headers"`
+	ResponseHeaders []ResponseHeader `json:"response_headers"`
+}
+
+// Attribute describes an hx-* attribute.
+type Attribute struct {
+	// Name is the attribute name without the hx- prefix, e.g. "get".
+	Name string `json:"name"`
+	// Func is the helper name without the Hx prefix, e.g. "Get".
+	Func string `json:"func"`
+	// Description is a short summary of what the attribute does.
+	Description string `json:"description"`
+	// Param, if set, is the name of an extra parameter appended to the
+	// attribute name, e.g. "eventName" for hx-on:[eventName].
+	Param string `json:"param,omitempty"`
+	// ExampleParam is the value of Param used in the generated example.
+	ExampleParam string `json:"example_param,omitempty"`
+	// Example is the attribute value used in the generated example.
+	Example    string `json:"example"`
+	Inherited  bool   `json:"inherited,omitempty"`
+	Deprecated bool   `json:"deprecated,omitempty"`
+	// Extension, if set, is the htmx extension that processes the
+	// attribute, e.g. "head-support". It must be enabled with hx-ext.
+	Extension string `json:"extension,omitempty"`
+	Versions  []int  `json:"versions"`
+	// Value is the grammar of the attribute value.
+	Value string `json:"value"`
+}
+
+// RequestHeader describes an HX-* request header.
+type RequestHeader struct {
+	Name string `json:"name"`
+	// Func is the helper name without the ServerGet prefix.
+	Func string `json:"func"`
+	// Kind is "present" for booleans that are true when the header is set,
+	// "true" for booleans that are true when the header equals "true" and
+	// "string" for headers returned as is.
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+	Example     string `json:"example,omitempty"`
+}
+
+// ResponseHeader describes an HX-* response header.
+type ResponseHeader struct {
+	Name string `json:"name"`
+	// Func is the helper name without the ServerSet prefix.
+	Func        string `json:"func"`
+	Description string `json:"description"`
+	DocURL      string `json:"doc_url,omitempty"`
+	Example     string `json:"example"`
+}
+
+// Key returns the hx- key rendered by the helper, e.g. "on:" for hx-on.
+func (a Attribute) Key() string {
+	if a.Param != "" {
+		return a.Name + ":"
+	}
+	return a.Name
+}
+
+// DocURL returns the attribute documentation URL, which is the extension
+// documentation for extension attributes.
+func (a Attribute) DocURL() string {
+	if a.Extension != "" {
+		return "https://htmx.org/extensions/" + a.Extension + "/"
+	}
+	return "https://htmx.org/attributes/hx-" + a.Name + "/"
+}
+
+// ExampleOutput returns the HTML rendered by the generated example.
+func (a Attribute) ExampleOutput() string {
+	return nodx.Div(nodx.Attr("hx-"+a.Key()+a.ExampleParam, a.Example)).String()
+}
+
+// output is a file generated from a template.
+type output struct {
+	template string
+	path     string
+	gofmt    bool
+}
+
+var outputs = []output{
+	{template: "htmx.go.tmpl", path: "htmx.go", gofmt: true},
+	{template: "htmx_test.go.tmpl", path: "htmx_test.go", gofmt: true},
+	{template: "htmx_attribute_spec.go.tmpl", path: "htmx_attribute_spec.go", gofmt: true},
+	{template: "htmx_server.go.tmpl", path: "htmx_server.go", gofmt: true},
+	{template: "htmx_server_test.go.tmpl", path: "htmx_server_test.go", gofmt: true},
+	{template: "REFERENCE.md.tmpl", path: "REFERENCE.md"},
+}
+
+func main() {
+	specPath := flag.String("spec", "internal/codegen/spec.json", "path to the spec file")
+	outDir := flag.String("out", ".", "directory where the files are generated")
+	flag.Parse()
+
+	spec, err := readSpec(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	files, err := generate(spec)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for path, content := range files {
+		if err := os.WriteFile(filepath.Join(*outDir, path), content, 0o644); err != nil {
+			log.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+}
+
+// readSpec reads and decodes the spec file.
+func readSpec(path string) (Spec, error) {
+	var spec Spec
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return spec, fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&spec); err != nil {
+		return spec, fmt.Errorf("failed to decode spec: %w", err)
+	}
+
+	return spec, nil
+}
+
+// generate renders every output and returns the contents by path.
+func generate(spec Spec) (map[string][]byte, error) {
+	tmpl, err := template.New("").Funcs(template.FuncMap{
+		"quote": goString,
+		"join":  joinInts,
+	}).ParseFS(templatesFS, "templates/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse templates: %w", err)
+	}
+
+	files := make(map[string][]byte, len(outputs))
+	for _, out := range outputs {
+		buf := &bytes.Buffer{}
+		if err := tmpl.ExecuteTemplate(buf, out.template, spec); err != nil {
+			return nil, fmt.Errorf("failed to execute %s: %w", out.template, err)
+		}
+
+		content := buf.Bytes()
+		if out.gofmt {
+			content, err = format.Source(content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to format %s: %w", out.path, err)
+			}
+		}
+		files[out.path] = content
+	}
+
+	return files, nil
+}
+
+// goString returns s as a Go string literal, using a raw string when it
+// contains double quotes to keep the generated code readable.
+func goString(s string) string {
+	if strings.Contains(s, `"`) && !strings.Contains(s, "`") {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+// joinInts joins the numbers with ", ".
+func joinInts(numbers []int) string {
+	parts := make([]string, len(numbers))
+	for i, n := range numbers {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ", ")
+}
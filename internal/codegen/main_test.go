@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	spec, err := readSpec("spec.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := generate(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, expected := range files {
+		got, err := os.ReadFile(filepath.Join("..", "..", path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, expected) {
+			t.Errorf("%s is out of date, run go generate ./...", path)
+		}
+	}
+}
+
+func TestGoString(t *testing.T) {
+	tests := map[string]string{
+		"<url>":               `"<url>"`,
+		`"true" | "false"`:    "`\"true\" | \"false\"`",
+		"quote \" and `tick`": "\"quote \\\" and `tick`\"",
+	}
+	for in, expected := range tests {
+		if got := goString(in); got != expected {
+			t.Errorf("goString(%q): expected %s, got %s", in, expected, got)
+		}
+	}
+}